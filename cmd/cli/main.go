@@ -1,8 +1,8 @@
 package main
 
 import (
+	"context"
 	"fmt"
-	"io"
 	"io/ioutil"
 	"log"
 	"os"
@@ -12,28 +12,145 @@ import (
 
 	"github.com/urfave/cli/v2"
 
+	"github.com/lukaskurz/rebyre/pkg/boole"
+	"github.com/lukaskurz/rebyre/pkg/dimacs"
 	"github.com/lukaskurz/rebyre/pkg/disjunction"
+	"github.com/lukaskurz/rebyre/pkg/formula"
+	"github.com/lukaskurz/rebyre/pkg/literal"
+	"github.com/lukaskurz/rebyre/pkg/proof"
+	"github.com/lukaskurz/rebyre/pkg/resolver"
+	"github.com/lukaskurz/rebyre/pkg/sat"
 )
 
-var (
-	index int
-	out   io.StringWriter
+// Exit codes of the commands deciding clauses. A refutation is the expected outcome of
+// the tool, so it exits cleanly, while a satisfiable input exits with a code
+// distinct from the one used for errors.
+const (
+	exitRefuted     = 0
+	exitSatisfiable = 2
+	exitUnknown     = 3
 )
 
-func main() {
-	index = 0
+// Input formats understood by the commands reading clauses
+const (
+	formatBoole   = "boole"
+	formatDimacs  = "dimacs"
+	formatFormula = "formula"
+)
+
+// Strategies of saturating the clause set
+const (
+	strategyLevel    = "level"
+	strategyShortest = "shortest"
+	strategyFIFO     = "fifo"
+	strategyWeighted = "weighted"
+)
+
+// Refinements of resolution
+const (
+	modeSaturation = "saturation"
+	modeLinear     = "linear"
+	modeInput      = "input"
+	modeOrdered    = "ordered"
+	modePositive   = "positive-hyper"
+	modeNegative   = "negative-hyper"
+)
+
+// Algorithms deciding the clause set
+const (
+	algorithmResolution = "resolution"
+	algorithmDP         = "dp"
+)
 
+// Layouts of printed proofs
+const (
+	proofTree = "tree"
+	proofDAG  = "dag"
+	proofDot  = "dot"
+)
+
+// Conversions of formulas into clauses
+const (
+	cnfNaive   = "naive"
+	cnfTseitin = "tseitin"
+)
+
+// out receives the result of a command, messages its verdicts, models and
+// statistics and terminal the progress of the resolution
+var out, messages, terminal *os.File
+
+var outputFlag = &cli.StringFlag{
+	Name:      "output",
+	Aliases:   []string{"o"},
+	Usage:     "output sets where solutions are streamed. keep it empty for STD (terminal output) or provide a file path",
+	Required:  false,
+	Hidden:    false,
+	TakesFile: true,
+}
+
+// inputFormatFlag is not called "format", which is the layout of printed proofs
+var inputFormatFlag = &cli.StringFlag{
+	Name:    "input-format",
+	Aliases: []string{"i"},
+	Usage:   "format of the input file, one of \"boole\", \"dimacs\" or \"formula\". keep it empty to choose by file extension (.cnf and .dimacs are read as DIMACS, .formula as formula)",
+}
+
+var cnfFlag = &cli.StringFlag{
+	Name:  "cnf",
+	Value: cnfNaive,
+	Usage: "conversion of formula input into clauses, either \"naive\" (equivalent, may grow exponentially) or \"tseitin\" (equisatisfiable, introduces variables t1, t2, ...)",
+}
+
+var strategyFlag = &cli.StringFlag{
+	Name:  "strategy",
+	Value: strategyLevel,
+	Usage: "how the clause set is saturated, either \"level\" (resolve all pairs round by round) or the given-clause algorithm picking the \"shortest\" clause, the oldest (\"fifo\") or the shortest but every fifth time the oldest (\"weighted\")",
+}
+
+var proofFormatFlag = &cli.StringFlag{
+	Name:  "format",
+	Value: proofTree,
+	Usage: "layout of printed proofs, either \"tree\" (every use of a clause repeats its derivation) \"dag\" (each derived clause is printed once with its id, later uses refer to it as [#id]) or \"dot\" (a Graphviz graph, use it with --output)",
+}
+
+var preprocessFlag = &cli.BoolFlag{
+	Name:  "preprocess",
+	Usage: "propagate unit clauses and eliminate pure literals before the saturation",
+}
+
+func main() {
 	solveCommand := &cli.Command{
 		Name:    "solve",
 		Aliases: []string{"s"},
 		Usage:   "rebyre solve <path/to/file.bool>",
 		Flags: []cli.Flag{
+			outputFlag,
+			inputFormatFlag,
+			cnfFlag,
+			strategyFlag,
+			preprocessFlag,
+			proofFormatFlag,
+			&cli.StringFlag{
+				Name:  "mode",
+				Value: modeSaturation,
+				Usage: "refinement of resolution, either \"saturation\" (resolve any clauses), \"linear\" (resolve the previous resolvent with an input clause or an ancestor) or \"input\" (resolve the previous resolvent with an input clause, only complete for Horn clauses) \"ordered\" (resolve on the greatest variable of both clauses), \"positive-hyper\" (resolve all negative literals of a clause with positive clauses at once) or \"negative-hyper\" (resolve all positive literals of a clause with negative clauses at once)",
+			},
+			&cli.StringFlag{
+				Name:  "algorithm",
+				Value: algorithmResolution,
+				Usage: "either \"resolution\" or \"dp\" for the Davis-Putnam procedure, which eliminates one variable after another by resolving all clauses containing it",
+			},
 			&cli.StringFlag{
-				Name:      "output",
-				Aliases:   []string{"o"},
-				Usage:     "output sets where solutions are streamed. keep it empty for STD (terminal output) or provide a file path",
-				Required:  false,
-				Hidden:    false,
+				Name:  "order",
+				Usage: "comma separated variables from the greatest to the smallest for the ordered mode, e.g. \"a,b,c\". variables missing from it are smaller, in the order they appear in",
+			},
+			&cli.BoolFlag{
+				Name:  "shortest",
+				Usage: "print only the refutation with the fewest resolution steps, counting steps shared by several branches once",
+			},
+			&cli.StringFlag{
+				Name:      "sos",
+				Usage:     "file with goal clauses, that are added to the input as set of support. resolution then always involves a goal or a clause derived from one",
 				TakesFile: true,
 			},
 		},
@@ -42,61 +159,309 @@ func main() {
 			if c.NArg() < 1 {
 				return fmt.Errorf("No file input specified")
 			}
-			text, err := readTextFromFile(c.Args().First())
+			if err := checkProofFormat(c.String("format")); err != nil {
+				return err
+			}
+
+			paths := []string{c.Args().First()}
+			if sos := c.String("sos"); sos != "" {
+				paths = append(paths, sos)
+			}
+			read, err := readEach(c.String("input-format"), c.String("cnf"), paths...)
 			if err != nil {
 				return err
 			}
 
-			disjunctions, err := parseDisjunctions(text)
+			disjunctions := read[0]
+			if len(read) > 1 {
+				for _, g := range read[1] {
+					g.Goal = true
+				}
+				disjunctions = append(disjunctions, read[1]...)
+			}
+
+			f, err := createOutput(c.String("output"))
 			if err != nil {
 				return err
 			}
+			setOutput(f, c.String("format"))
+			defer closeOutput(f)
 
-			oFlag := c.String("output")
-			if len(strings.TrimSpace(oFlag)) == 0 {
-				out = os.Stdout
-			} else {
-				abs, err := filepath.Abs(oFlag)
+			solver, err := newSolver(c)
+			if err != nil {
+				return err
+			}
+			if order := c.String("order"); order != "" {
+				if solver.Mode != resolver.Ordered {
+					return fmt.Errorf("An order can only be given in the \"%s\" mode", modeOrdered)
+				}
+				solver.Order, err = parseOrder(order, disjunction.Variables(disjunctions))
 				if err != nil {
 					return err
 				}
+			}
+			chained := solver.Mode == resolver.Linear || solver.Mode == resolver.Input
+
+			terminal.WriteString("Starting resolution:\n")
+
+			result, err := solver.Solve(context.Background(), disjunctions)
+			if err != nil {
+				return err
+			}
+			if verbose {
+				printStats(result.Stats)
+			}
+
+			if result.Verdict == resolver.Unknown {
+				messages.WriteString("UNKNOWN: input resolution found no refutation, which it may miss as not all clauses are Horn clauses\n")
+				return cli.Exit("", exitUnknown)
+			}
+
+			if result.Verdict == resolver.Satisfiable {
+				switch {
+				case solver.Mode == resolver.DavisPutnam:
+					messages.WriteString("All variables were eliminated without deriving the empty clause.\n")
+				case chained:
+					messages.WriteString("No chain of resolvents leads to the empty clause.\n")
+				default:
+					messages.WriteString("No new clauses could be derived, the clause set is saturated.\n")
+				}
+				messages.WriteString("SATISFIABLE: no refutation exists\n")
+				if result.Model != nil {
+					messages.WriteString(fmt.Sprintf("Model: %s\n", result.Model))
+				} else if chained {
+					messages.WriteString("No model could be constructed, as the linear search leaves no saturated clause set\n")
+				} else {
+					messages.WriteString("No model could be constructed from the saturated clause set\n")
+				}
+				return cli.Exit("", exitSatisfiable)
+			}
+
+			if result.Propagated {
+				messages.WriteString("Unit propagation derived an empty clause !!\n")
+			} else {
+				terminal.WriteString("Found an empty clause !!\n")
+			}
+			messages.WriteString("UNSATISFIABLE: refutation found\n")
+			// a chain prints every clause once already, the other layouts
+			// show it like any other proof. Side clauses derived by unit
+			// propagation have derivations of their own, which only a tree shows
+			if chained && !result.Propagated && c.String("format") == proofTree && proof.IsChain(result, result.Refutations[0]) {
+				out.WriteString("\n")
+				if err := proof.WriteChain(out, result, result.Refutations[0]); err != nil {
+					return err
+				}
+			} else if err := printRefutations(result, c.String("format")); err != nil {
+				return err
+			}
+
+			return cli.Exit("", exitRefuted)
+		},
+	}
+
+	convertCommand := &cli.Command{
+		Name:    "convert",
+		Aliases: []string{"export"},
+		Usage:   "rebyre convert <path/to/file.boole> writes the clauses in DIMACS CNF format",
+		Flags: []cli.Flag{
+			outputFlag,
+			inputFormatFlag,
+			cnfFlag,
+		},
+		Action: func(c *cli.Context) error {
+			if c.NArg() < 1 {
+				return fmt.Errorf("No file input specified")
+			}
+
+			disjunctions, err := readDisjunctions(c.Args().First(), c.String("input-format"), c.String("cnf"))
+			if err != nil {
+				return err
+			}
+
+			f, err := createOutput(c.String("output"))
+			if err != nil {
+				return err
+			}
+			defer closeOutput(f)
+
+			return dimacs.Write(f, disjunctions)
+		},
+	}
+
+	entailsCommand := &cli.Command{
+		Name:    "entails",
+		Aliases: []string{"e"},
+		Usage:   "rebyre entails <path/to/kb.boole> <query> proves that the knowledge base entails the query formula",
+		Flags: []cli.Flag{
+			outputFlag,
+			inputFormatFlag,
+			cnfFlag,
+			strategyFlag,
+			preprocessFlag,
+			proofFormatFlag,
+		},
+		Action: func(c *cli.Context) error {
+			verbose := c.Bool("verbose")
+			if c.NArg() < 2 {
+				return fmt.Errorf("Knowledge base file and query have to be specified")
+			}
+			if err := checkProofFormat(c.String("format")); err != nil {
+				return err
+			}
 
-				f, err := os.Create(abs)
+			query, err := formula.Parse(c.Args().Get(1))
+			if err != nil {
+				return fmt.Errorf("query:%w", err)
+			}
+
+			// a formula knowledge base is clausified along with the query, so the
+			// fresh variables of a Tseitin conversion never clash
+			var kb, negated []*disjunction.Disjunction
+			var variables []string
+			path := c.Args().First()
+			if inputFormat(path, c.String("input-format")) == formatFormula {
+				f, err := readFormula(path)
 				if err != nil {
 					return err
 				}
-				out = f
-				defer func() {
-					err = f.Close()
-					fmt.Println(err)
-				}()
+				converted, err := clausifyEach(c.String("cnf"), nil, f, &formula.Not{F: query})
+				if err != nil {
+					return err
+				}
+				kb, negated = converted[0], converted[1]
+				variables = formula.Variables(f)
+			} else {
+				kb, err = readDisjunctions(path, c.String("input-format"), c.String("cnf"))
+				if err != nil {
+					return err
+				}
+				variables = disjunction.Variables(kb)
+				converted, err := clausifyEach(c.String("cnf"), variables, &formula.Not{F: query})
+				if err != nil {
+					return err
+				}
+				negated = converted[0]
+			}
+
+			// the negated query is the set of support, since the knowledge
+			// base alone is expected to be consistent
+			for _, n := range negated {
+				n.Goal = true
 			}
+			disjunctions := append(kb, negated...)
 
+			known := map[string]bool{}
+			for _, v := range variables {
+				known[v] = true
+			}
+			for _, v := range formula.Variables(query) {
+				if !known[v] {
+					variables = append(variables, v)
+				}
+			}
+
+			f, err := createOutput(c.String("output"))
+			if err != nil {
+				return err
+			}
+			setOutput(f, c.String("format"))
+			defer closeOutput(f)
+
+			solver, err := newSolver(c)
+			if err != nil {
+				return err
+			}
+
+			result, err := solver.Solve(context.Background(), disjunctions)
+			if err != nil {
+				return err
+			}
 			if verbose {
-				printDisjunctions(disjunctions)
+				printStats(result.Stats)
 			}
-			fmt.Println("Starting resolution:")
 
-			emptyClauses := make([]*disjunction.Disjunction, 0)
-			for len(emptyClauses) == 0 {
-				combinations := combineDisjunctions(disjunctions)
-				if verbose {
-					printCombinations(combinations)
+			if result.Verdict == resolver.Satisfiable {
+				messages.WriteString(fmt.Sprintf("NOT ENTAILED: the knowledge base does not entail %s\n", query))
+				if result.Model != nil {
+					countermodel := resolver.Model{}
+					for _, v := range variables {
+						countermodel[v] = result.Model[v]
+					}
+					messages.WriteString(fmt.Sprintf("Countermodel: %s\n", countermodel))
 				}
+				return cli.Exit("", exitSatisfiable)
+			}
+
+			messages.WriteString(fmt.Sprintf("ENTAILED: the knowledge base entails %s\n", query))
+			if err := printRefutations(result, c.String("format")); err != nil {
+				return err
+			}
+
+			return cli.Exit("", exitRefuted)
+		},
+	}
 
-				disjunctions = append(disjunctions, combinations...)
+	satCommand := &cli.Command{
+		Name:  "sat",
+		Usage: "rebyre sat <path/to/file.boole> decides the clauses by conflict driven clause learning, which scales far beyond resolution",
+		Flags: []cli.Flag{
+			outputFlag,
+			inputFormatFlag,
+			cnfFlag,
+			proofFormatFlag,
+			&cli.BoolFlag{
+				Name:  "proof",
+				Usage: "print a refutation by resolution of unsatisfiable input, put together from the clauses learned on the way",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			verbose := c.Bool("verbose")
+			if c.NArg() < 1 {
+				return fmt.Errorf("No file input specified")
+			}
+			if err := checkProofFormat(c.String("format")); err != nil {
+				return err
+			}
+
+			disjunctions, err := readDisjunctions(c.Args().First(), c.String("input-format"), c.String("cnf"))
+			if err != nil {
+				return err
+			}
 
-				emptyClauses = getEmptyClauses(disjunctions)
+			f, err := createOutput(c.String("output"))
+			if err != nil {
+				return err
 			}
+			setOutput(f, c.String("format"))
+			defer closeOutput(f)
 
-			fmt.Println("Found an empty clause !!")
+			result, err := (&sat.Solver{Proof: c.Bool("proof")}).Solve(context.Background(), disjunctions)
+			if err != nil {
+				return err
+			}
+			if verbose {
+				messages.WriteString(fmt.Sprintf("decisions: %d, propagations: %d, conflicts: %d, learned clauses: %d, restarts: %d\n",
+					result.Stats.Decisions, result.Stats.Propagations, result.Stats.Conflicts, result.Stats.Learned, result.Stats.Restarts))
+			}
 
-			for i, e := range emptyClauses {
-				out.WriteString(fmt.Sprintf("\nSolution #%d\n\n", i))
-				printTree(disjunctions, e, "", true)
+			if result.Satisfiable {
+				messages.WriteString("SATISFIABLE\n")
+				messages.WriteString(fmt.Sprintf("Model: %s\n", resolver.Model(result.Model)))
+				return cli.Exit("", exitSatisfiable)
 			}
 
-			return nil
+			if result.Refutation == nil {
+				messages.WriteString("UNSATISFIABLE\n")
+				return cli.Exit("", exitRefuted)
+			}
+			messages.WriteString("UNSATISFIABLE\n")
+			if c.String("format") != proofDot {
+				out.WriteString("\nRefutation\n\n")
+			}
+			if err := printProof(result, result.Refutation, c.String("format")); err != nil {
+				return err
+			}
+			return cli.Exit("", exitRefuted)
 		},
 	}
 
@@ -108,6 +473,9 @@ func main() {
 		Version:              "4.20.69",
 		Commands: []*cli.Command{
 			solveCommand,
+			entailsCommand,
+			satCommand,
+			convertCommand,
 		},
 		Flags: []cli.Flag{
 			&cli.BoolFlag{Name: "verbose"},
@@ -118,7 +486,7 @@ func main() {
 				Email: "me@lukaskurz.com",
 			},
 		},
-		Usage: "Tool to do a refutation by resolution on a proposition in CNF. Input has to be in CNF i.e. ( a | b | !c ) & ( !a | b)",
+		Usage: "Tool to do a refutation by resolution on a proposition. Input is in CNF i.e. ( a | b | !c ) & ( !a | b), or an arbitrary formula i.e. (a -> b) <-> !c with --input-format formula",
 	}
 
 	err := app.Run(os.Args)
@@ -127,128 +495,331 @@ func main() {
 	}
 }
 
-func printTree(all []*disjunction.Disjunction, d *disjunction.Disjunction, indent string, left bool) {
-	text := d.String()
+// setOutput directs the result and the verdicts of a command to f and its progress
+// to stdout. A DOT graph has to be all of the output, so with the dot format only
+// the graph goes to f and everything else to stderr.
+func setOutput(f *os.File, format string) {
+	out, messages, terminal = f, f, os.Stdout
+	if format == proofDot {
+		messages, terminal = os.Stderr, os.Stderr
+	}
+}
 
-	if len(indent) > 0 {
-		if left {
-			out.WriteString("T")
-		} else {
-			out.WriteString(fmt.Sprintf("%s└", indent))
-		}
+// createOutput opens the file at path for writing, or returns stdout for an empty path
+func createOutput(path string) (*os.File, error) {
+	if len(strings.TrimSpace(path)) == 0 {
+		return os.Stdout, nil
 	}
-	out.WriteString(text)
 
-	nextIndent := indent
-	for i := 0; i < len(text); i++ {
-		nextIndent += " "
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return os.Create(abs)
+}
+
+func closeOutput(f *os.File) {
+	if f == os.Stdout {
+		return
+	}
+	if err := f.Close(); err != nil {
+		fmt.Println(err)
+	}
+}
+
+// newSolver creates a solver as configured by the flags of the command
+func newSolver(c *cli.Context) (*resolver.Solver, error) {
+	solver := &resolver.Solver{Preprocess: c.Bool("preprocess"), Shortest: c.Bool("shortest")}
+	if c.Bool("verbose") {
+		solver.OnInput = printDisjunctions
+		solver.OnPreprocess = printPreprocessing
+		solver.OnEliminate = printElimination
+		solver.OnRound = printCombinations
 	}
 
-	if !left {
-		nextIndent += " "
+	switch c.String("mode") {
+	case "", modeSaturation:
+	case modeLinear:
+		solver.Mode = resolver.Linear
+	case modeInput:
+		solver.Mode = resolver.Input
+	case modeOrdered:
+		solver.Mode = resolver.Ordered
+	case modePositive:
+		solver.Mode = resolver.PositiveHyper
+	case modeNegative:
+		solver.Mode = resolver.NegativeHyper
+	default:
+		return nil, fmt.Errorf("Unknown mode \"%s\"", c.String("mode"))
 	}
 
-	if d.SourceA != 0 {
-		next := getDisjunction(d.SourceA, all)
-		printTree(all, next, nextIndent+"|", true)
+	switch c.String("strategy") {
+	case strategyLevel:
+	case strategyShortest:
+		solver.Selector = resolver.ShortestFirst{}
+	case strategyFIFO:
+		solver.Selector = resolver.FIFO{}
+	case strategyWeighted:
+		solver.Selector = resolver.AgeWeight{PickRatio: 4}
+	default:
+		return nil, fmt.Errorf("Unknown strategy \"%s\"", c.String("strategy"))
 	}
-	if d.SourceB != 0 {
-		next := getDisjunction(d.SourceB, all)
-		printTree(all, next, nextIndent, false)
+	// only saturation picks clauses, the other modes resolve in an order of their own
+	if solver.Selector != nil && solver.Mode != resolver.Saturation && solver.Mode != resolver.Ordered {
+		return nil, fmt.Errorf("The \"%s\" mode can't be combined with a strategy", c.String("mode"))
 	}
-	if d.SourceA == 0 && d.SourceB == 0 {
-		out.WriteString("\n")
+	// hyperresolution always resolves with the nuclei among the input clauses and
+	// input resolution with any input clause, so neither restricts itself to goals
+	switch solver.Mode {
+	case resolver.PositiveHyper, resolver.NegativeHyper, resolver.Input:
+		if c.String("sos") != "" {
+			return nil, fmt.Errorf("The \"%s\" mode can't be combined with a set of support", c.String("mode"))
+		}
 	}
 
+	switch c.String("algorithm") {
+	case "", algorithmResolution:
+	case algorithmDP:
+		// Davis-Putnam eliminates variable by variable, it never picks clauses or goals
+		if solver.Mode != resolver.Saturation {
+			return nil, fmt.Errorf("A mode can't be combined with the \"%s\" algorithm", algorithmDP)
+		}
+		if solver.Selector != nil {
+			return nil, fmt.Errorf("A strategy can't be combined with the \"%s\" algorithm", algorithmDP)
+		}
+		if c.String("sos") != "" {
+			return nil, fmt.Errorf("A set of support can't be combined with the \"%s\" algorithm", algorithmDP)
+		}
+		solver.Mode = resolver.DavisPutnam
+	default:
+		return nil, fmt.Errorf("Unknown algorithm \"%s\"", c.String("algorithm"))
+	}
+
+	return solver, nil
 }
 
-func getDisjunction(id int, all []*disjunction.Disjunction) *disjunction.Disjunction {
-	for _, e := range all {
-		if e.ID() == id {
-			return e
+func printRefutations(result *resolver.Result, format string) error {
+	if format == proofDot {
+		return proof.WriteDot(out, result, result.Refutations)
+	}
+	for i, e := range result.Refutations {
+		out.WriteString(fmt.Sprintf("\nSolution #%d\n\n", i))
+		if err := printProof(result, e, format); err != nil {
+			return err
 		}
 	}
-
 	return nil
 }
 
+// checkProofFormat rejects unknown layouts of proofs before any work is done
+func checkProofFormat(format string) error {
+	switch format {
+	case proofTree, proofDAG, proofDot:
+		return nil
+	default:
+		return fmt.Errorf("Unknown format \"%s\"", format)
+	}
+}
+
+// printProof writes the derivation of d in the given layout
+func printProof(result proof.Derivations, d *disjunction.Disjunction, format string) error {
+	switch format {
+	case proofDot:
+		return proof.WriteDot(out, result, []*disjunction.Disjunction{d})
+	case proofDAG:
+		return proof.WriteDAG(out, result, d)
+	default:
+		return proof.WriteTree(out, result, d)
+	}
+}
+
+// parseOrder splits the comma separated variables of an order and checks that
+// each of them is one of the known variables.
+//
+// Example: "a, b,c"
+func parseOrder(text string, variables []string) ([]string, error) {
+	known := map[string]bool{}
+	for _, v := range variables {
+		known[v] = true
+	}
+
+	order := strings.Split(text, ",")
+	for i, v := range order {
+		order[i] = strings.TrimSpace(v)
+		if !known[order[i]] {
+			return nil, fmt.Errorf("Unknown variable \"%s\" in order", order[i])
+		}
+	}
+	return order, nil
+}
+
 func printDisjunctions(disjunctions []*disjunction.Disjunction) {
 	for _, d := range disjunctions {
-		out.WriteString(fmt.Sprintf("%d %s\n", d.ID(), d.String()))
+		if d.Goal {
+			messages.WriteString(fmt.Sprintf("%d %s goal\n", d.ID(), d.String()))
+		} else {
+			messages.WriteString(fmt.Sprintf("%d %s\n", d.ID(), d.String()))
+		}
 	}
 }
 
 func printCombinations(combinations []*disjunction.Disjunction) {
 	for _, c := range combinations {
-		out.WriteString(fmt.Sprintf("%d %s %d %d\n", c.ID(), c.String(), c.SourceA, c.SourceB))
+		parents := ""
+		for _, id := range c.Parents() {
+			parents += fmt.Sprintf(" %d", id)
+		}
+		messages.WriteString(fmt.Sprintf("%d %s%s\n", c.ID(), c.String(), parents))
 	}
 }
 
-func parseDisjunctions(text string) ([]*disjunction.Disjunction, error) {
-	splitted := strings.Split(text, "&")
-	disjunctions := make([]*disjunction.Disjunction, len(splitted))
+func printPreprocessing(derived []*disjunction.Disjunction, pure []*literal.Literal) {
+	if len(derived) > 0 {
+		messages.WriteString("Unit propagation:\n")
+		printCombinations(derived)
+	}
 
-	for i, s := range splitted {
-		var err error
-		disjunctions[i], err = disjunction.DisjunctionFromString(s)
-		if err != nil {
-			return nil, err
+	if len(pure) > 0 {
+		literals := make([]string, len(pure))
+		for i, l := range pure {
+			literals[i] = l.String()
 		}
+		messages.WriteString(fmt.Sprintf("Pure literals: %s\n", strings.Join(literals, ", ")))
 	}
+}
 
-	return disjunctions, nil
+func printElimination(variable string, removed []*disjunction.Disjunction, derived []*disjunction.Disjunction) {
+	messages.WriteString(fmt.Sprintf("Eliminating %s: %d clauses replaced by %d resolvents\n", variable, len(removed), len(derived)))
+	printCombinations(derived)
 }
 
-func readTextFromFile(filepath string) (string, error) {
-	buffer, err := ioutil.ReadFile(filepath)
-	if err != nil {
-		return "", err
+// inputFormat returns the format given by flag, or derives it from the file extension if flag is empty
+func inputFormat(path string, flag string) string {
+	if flag != "" {
+		return flag
 	}
-	text := string(buffer)
-	text = strings.ReplaceAll(text, "\n", "")
-	text = strings.ReplaceAll(text, "\r", "")
-	text = strings.ReplaceAll(text, " ", "")
 
-	return text, nil
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".cnf", ".dimacs":
+		return formatDimacs
+	case ".formula":
+		return formatFormula
+	default:
+		return formatBoole
+	}
 }
 
-func getEmptyClauses(disjunctions []*disjunction.Disjunction) []*disjunction.Disjunction {
-	clauses := make([]*disjunction.Disjunction, 0)
+func printStats(stats resolver.Stats) {
+	messages.WriteString(fmt.Sprintf("rounds: %d, tautologies discarded: %d, forward subsumed: %d, backward subsumed: %d, unit resolutions: %d, pure literals: %d\n",
+		stats.Rounds, stats.Tautologies, stats.ForwardSubsumed, stats.BackwardSubsumed, stats.UnitResolutions, stats.PureLiterals))
+}
 
-	for _, d := range disjunctions {
-		if d.IsEmpty() {
-			clauses = append(clauses, d)
+func readDisjunctions(path string, format string, cnf string) ([]*disjunction.Disjunction, error) {
+	switch inputFormat(path, format) {
+	case formatBoole:
+		buffer, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
 		}
+		return boole.Parse(path, string(buffer))
+	case formatDimacs:
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		disjunctions, err := dimacs.Read(f)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		return disjunctions, nil
+	case formatFormula:
+		f, err := readFormula(path)
+		if err != nil {
+			return nil, err
+		}
+		return clausify(f, cnf)
+	default:
+		return nil, fmt.Errorf("Unknown input format \"%s\"", format)
 	}
-	return clauses
 }
 
-func combineDisjunctions(disjunctions []*disjunction.Disjunction) []*disjunction.Disjunction {
-	length := len(disjunctions)
-
-	combinations := make([]*disjunction.Disjunction, 0)
-
-	for _, base := range disjunctions[index:] {
-		for _, target := range disjunctions {
-			if base.CompatibleWith(target) {
-				derived := base.Derive(target)
-				if !isClauseContained(disjunctions, derived) && !isClauseContained(combinations, derived) {
-					combinations = append(combinations, derived)
-				}
+// readEach reads the clauses of each file. The formulas among them are clausified
+// together, so the fresh variables of a Tseitin conversion clash neither with
+// each other nor with the variables of the other files.
+func readEach(format string, cnf string, paths ...string) ([][]*disjunction.Disjunction, error) {
+	read := make([][]*disjunction.Disjunction, len(paths))
+	taken := make([]string, 0)
+	formulas := make([]formula.Formula, 0)
+	positions := make([]int, 0)
+	for i, path := range paths {
+		if inputFormat(path, format) != formatFormula {
+			var err error
+			read[i], err = readDisjunctions(path, format, cnf)
+			if err != nil {
+				return nil, err
 			}
+			taken = append(taken, disjunction.Variables(read[i])...)
+			continue
+		}
+
+		f, err := readFormula(path)
+		if err != nil {
+			return nil, err
 		}
+		formulas = append(formulas, f)
+		positions = append(positions, i)
 	}
 
-	index = length
+	converted, err := clausifyEach(cnf, taken, formulas...)
+	if err != nil {
+		return nil, err
+	}
+	for j, i := range positions {
+		read[i] = converted[j]
+	}
+	return read, nil
+}
+
+func readFormula(path string) (formula.Formula, error) {
+	buffer, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
 
-	return combinations
+	f, err := formula.Parse(string(buffer))
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", path, err)
+	}
+	return f, nil
 }
 
-func isClauseContained(clauses []*disjunction.Disjunction, clause *disjunction.Disjunction) bool {
-	for _, c := range clauses {
-		if c.Equals(clause) {
-			return true
+// clausifyEach converts each of the formulas into clauses. The fresh variables
+// of a Tseitin conversion are shared by none of them and none of the taken variables.
+func clausifyEach(cnf string, taken []string, fs ...formula.Formula) ([][]*disjunction.Disjunction, error) {
+	switch cnf {
+	case cnfNaive:
+		converted := make([][]*disjunction.Disjunction, len(fs))
+		for i, f := range fs {
+			converted[i] = formula.CNF(f)
 		}
+		return converted, nil
+	case cnfTseitin:
+		return formula.TseitinEach(taken, fs...), nil
+	default:
+		return nil, fmt.Errorf("Unknown CNF conversion \"%s\"", cnf)
+	}
+}
+
+func clausify(f formula.Formula, cnf string) ([]*disjunction.Disjunction, error) {
+	switch cnf {
+	case cnfNaive:
+		return formula.CNF(f), nil
+	case cnfTseitin:
+		return formula.Tseitin(f), nil
+	default:
+		return nil, fmt.Errorf("Unknown CNF conversion \"%s\"", cnf)
 	}
-	return false
 }
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lukaskurz/rebyre/pkg/proof"
+	"github.com/lukaskurz/rebyre/pkg/resolver"
+)
+
+func TestReadEachTseitin(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rebyre")
+	if err != nil {
+		t.Fatalf("FAILED, got an error: %s", err.Error())
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"kb.boole":     "t1 | a\n!t1\n",
+		"kb.formula":   "(t1 | a) & !t1",
+		"goal.formula": "a -> b",
+	}
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("FAILED, got an error: %s", err.Error())
+		}
+	}
+
+	// the fresh variables of the goal must not be named like t1 of the knowledge
+	// base, which would make the satisfiable clauses unsatisfiable
+	for _, kb := range []string{"kb.boole", "kb.formula"} {
+		read, err := readEach("", cnfTseitin, filepath.Join(dir, kb), filepath.Join(dir, "goal.formula"))
+		if err != nil {
+			t.Fatalf("FAILED, got an error with %s: %s", kb, err.Error())
+		}
+		if len(read) != 2 {
+			t.Fatalf("FAILED, expected the clauses of 2 files, got %d", len(read))
+		}
+
+		result, err := (&resolver.Solver{}).Solve(context.Background(), append(read[0], read[1]...))
+		if err != nil {
+			t.Fatalf("FAILED, got an error: %s", err.Error())
+		}
+		if result.Verdict != resolver.Satisfiable {
+			t.Errorf("FAILED, expected %s with the goal to be %s, not %s", kb, resolver.Satisfiable, result.Verdict)
+		}
+	}
+}
+
+func TestPreprocessedChain(t *testing.T) {
+	clauses, err := readDisjunctions("../../example_input.boole", "", cnfNaive)
+	if err != nil {
+		t.Fatalf("FAILED, got an error: %s", err.Error())
+	}
+
+	// unit propagation derives side clauses like ( c | y ), whose derivation
+	// a chain can't show
+	result, err := (&resolver.Solver{Mode: resolver.Linear, Preprocess: true}).Solve(context.Background(), clauses)
+	if err != nil {
+		t.Fatalf("FAILED, got an error: %s", err.Error())
+	}
+	if result.Verdict != resolver.Unsatisfiable || result.Propagated {
+		t.Fatalf("FAILED, expected a linear refutation, got %s", result.Verdict)
+	}
+	if proof.IsChain(result, result.Refutations[0]) {
+		t.Errorf("FAILED, expected the refutation to use side clauses derived by unit propagation")
+	}
+
+	result, err = (&resolver.Solver{Mode: resolver.Linear}).Solve(context.Background(), clauses)
+	if err != nil {
+		t.Fatalf("FAILED, got an error: %s", err.Error())
+	}
+	if !proof.IsChain(result, result.Refutations[0]) {
+		t.Errorf("FAILED, expected a linear refutation from the input clauses to be a chain")
+	}
+}
@@ -0,0 +1,254 @@
+package boole
+
+import (
+	"fmt"
+	"unicode"
+
+	"github.com/lukaskurz/rebyre/pkg/disjunction"
+	"github.com/lukaskurz/rebyre/pkg/literal"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenVar
+	tokenNot
+	tokenAnd
+	tokenOr
+	tokenOpen
+	tokenClose
+	tokenNewline
+	tokenColon
+)
+
+// GoalPrefix marks a clause as goal, i.e. part of the set of support, when written in front of it
+const GoalPrefix = "goal"
+
+type token struct {
+	kind tokenKind
+	text string
+	line int
+	col  int
+}
+
+// Parse parses a proposition in CNF written in the .boole format.
+// The name is used as prefix of error messages, which point to the offending character.
+//
+// Clauses are separated by "&" or by line breaks, a clause only continues on the next
+// line after a "|" or inside parentheses. Text after "#" or "//" is a comment up to the
+// end of the line. Clauses prefixed with "goal:" are marked as goals.
+//
+// Example:
+//
+//	# weather rules
+//	( a | !b ) &
+//	( !a | c )  // trailing comment
+//	goal: !c | d
+//
+// Error: "input.boole:1:7: unexpected '1'"
+func Parse(name string, text string) ([]*disjunction.Disjunction, error) {
+	p := &parser{name: name}
+
+	var err error
+	p.tokens, err = p.tokenize(text)
+	if err != nil {
+		return nil, err
+	}
+
+	disjunctions := make([]*disjunction.Disjunction, 0)
+	p.skipNewlines()
+	for {
+		d, err := p.parseClause()
+		if err != nil {
+			return nil, err
+		}
+		disjunctions = append(disjunctions, d)
+
+		// the clause has to be followed by "&", a line break or both
+		t := p.peek()
+		if t.kind != tokenAnd && t.kind != tokenNewline && t.kind != tokenEOF {
+			return nil, p.unexpected(t)
+		}
+		p.skipNewlines()
+		if p.peek().kind == tokenAnd {
+			p.next()
+			p.skipNewlines()
+		} else if p.peek().kind == tokenEOF {
+			return disjunctions, nil
+		}
+	}
+}
+
+type parser struct {
+	name   string
+	tokens []token
+	pos    int
+}
+
+func (p *parser) tokenize(text string) ([]token, error) {
+	runes := []rune(text)
+	tokens := make([]token, 0)
+
+	line, col := 1, 1
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		t := token{line: line, col: col}
+		length := 1
+
+		switch {
+		case r == '\n':
+			tokens = append(tokens, token{kind: tokenNewline, text: "\n", line: line, col: col})
+			line++
+			col = 1
+			i++
+			continue
+		case r == '#' || (r == '/' && i+1 < len(runes) && runes[i+1] == '/'):
+			// comments are skipped up to, but not including, the line break
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+			continue
+		case unicode.IsSpace(r):
+		case isLetter(r):
+			for i+length < len(runes) && (isLetter(runes[i+length]) || isDigit(runes[i+length])) {
+				length++
+			}
+			t.kind = tokenVar
+		case r == '!':
+			t.kind = tokenNot
+		case r == '&':
+			t.kind = tokenAnd
+		case r == '|':
+			t.kind = tokenOr
+		case r == '(':
+			t.kind = tokenOpen
+		case r == ')':
+			t.kind = tokenClose
+		case r == ':':
+			t.kind = tokenColon
+		default:
+			return nil, fmt.Errorf("%s:%d:%d: unexpected '%c'", p.name, line, col, r)
+		}
+
+		if !unicode.IsSpace(r) {
+			t.text = string(runes[i : i+length])
+			tokens = append(tokens, t)
+		}
+		i += length
+		col += length
+	}
+
+	return append(tokens, token{kind: tokenEOF, line: line, col: col}), nil
+}
+
+func isLetter(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+func (p *parser) unexpected(t token) error {
+	switch t.kind {
+	case tokenEOF:
+		return fmt.Errorf("%s:%d:%d: unexpected end of input", p.name, t.line, t.col)
+	case tokenNewline:
+		return fmt.Errorf("%s:%d:%d: unexpected end of line", p.name, t.line, t.col)
+	}
+	return fmt.Errorf("%s:%d:%d: unexpected '%s'", p.name, t.line, t.col, t.text)
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) skipNewlines() {
+	for p.peek().kind == tokenNewline {
+		p.next()
+	}
+}
+
+// parseClause parses literals separated by "|", optionally enclosed in parentheses
+// and preceded by the goal prefix. Parentheses may also enclose no literal at all,
+// which is the empty clause. Line breaks are skipped inside parentheses and after "|".
+func (p *parser) parseClause() (*disjunction.Disjunction, error) {
+	goal := false
+	if t := p.peek(); t.kind == tokenVar && t.text == GoalPrefix && p.tokens[p.pos+1].kind == tokenColon {
+		p.next()
+		p.next()
+		goal = true
+	}
+
+	d, err := p.parseDisjunction()
+	if err != nil {
+		return nil, err
+	}
+	d.Goal = goal
+
+	return d, nil
+}
+
+func (p *parser) parseDisjunction() (*disjunction.Disjunction, error) {
+	enclosed := p.peek().kind == tokenOpen
+	if enclosed {
+		p.next()
+		p.skipNewlines()
+		if p.peek().kind == tokenClose {
+			p.next()
+			return disjunction.New(), nil
+		}
+	}
+
+	literals := make([]*literal.Literal, 0)
+	for {
+		l, err := p.parseLiteral()
+		if err != nil {
+			return nil, err
+		}
+		literals = append(literals, l)
+
+		if enclosed {
+			p.skipNewlines()
+		}
+		if p.peek().kind != tokenOr {
+			break
+		}
+		p.next()
+		p.skipNewlines()
+	}
+
+	if enclosed {
+		if t := p.next(); t.kind != tokenClose {
+			return nil, p.unexpected(t)
+		}
+	}
+
+	return disjunction.New(literals...), nil
+}
+
+// parseLiteral parses a variable preceded by any number of negation signs
+func (p *parser) parseLiteral() (*literal.Literal, error) {
+	negated := false
+	for p.peek().kind == tokenNot {
+		p.next()
+		p.skipNewlines()
+		negated = !negated
+	}
+
+	t := p.next()
+	if t.kind != tokenVar {
+		return nil, p.unexpected(t)
+	}
+
+	return literal.New(t.text, negated), nil
+}
@@ -0,0 +1,105 @@
+package boole
+
+import (
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	valids := []struct {
+		s string
+		d []string
+	}{
+		{"a", []string{"( a )"}},
+		{"( a | !b | c )", []string{"( a | !b | c )"}},
+		{"( x | !d | !a ) &\n( !c | a | !d ) &\n( b )", []string{"( x | !d | !a )", "( !c | a | !d )", "( b )"}},
+		{"!!a | !!!b & c", []string{"( a | !b )", "( c )"}},
+		{"(a|b)&(!a)", []string{"( a | b )", "( !a )"}},
+		{"( ) & a", []string{"(  )", "( a )"}},
+		{"\r\n\t( mythical )\r\n", []string{"( mythical )"}},
+		{"a | b\n!a\n\n\nc | !b", []string{"( a | b )", "( !a )", "( c | !b )"}},
+		{"( a |\n  b ) & ( c\n)\n& d |\n e", []string{"( a | b )", "( c )", "( d | e )"}},
+		{"# rules\na | b # first\n// second\n!a // negated\n#", []string{"( a | b )", "( !a )"}},
+		{"( a ) & # comment after and\n( b )", []string{"( a )", "( b )"}},
+		{"a//b\nc", []string{"( a )", "( c )"}},
+		{"x1 | !x23 & t1b", []string{"( x1 | !x23 )", "( t1b )"}},
+	}
+
+	for _, v := range valids {
+		disjunctions, err := Parse("test", v.s)
+		if err != nil {
+			t.Errorf("FAILED, expected no error for %q, got %s", v.s, err.Error())
+			continue
+		}
+		if len(disjunctions) != len(v.d) {
+			t.Errorf("FAILED, expected %d disjunctions for %q, not %d", len(v.d), v.s, len(disjunctions))
+			continue
+		}
+		for i, d := range disjunctions {
+			if d.String() != v.d[i] {
+				t.Errorf("FAILED, expected disjunction %d of %q to be %s, not %s", i, v.s, v.d[i], d)
+			}
+		}
+	}
+}
+
+func TestParseGoal(t *testing.T) {
+	disjunctions, err := Parse("test", "a | b\ngoal: !a\ngoal:(!b)\n( goal | c ) & goal\n")
+	if err != nil {
+		t.Fatalf("FAILED, got an error: %s", err.Error())
+	}
+
+	expected := []struct {
+		d    string
+		goal bool
+	}{
+		{"( a | b )", false},
+		{"( !a )", true},
+		{"( !b )", true},
+		{"( goal | c )", false},
+		{"( goal )", false},
+	}
+
+	if len(disjunctions) != len(expected) {
+		t.Fatalf("FAILED, expected %d disjunctions, not %d", len(expected), len(disjunctions))
+	}
+	for i, e := range expected {
+		if disjunctions[i].String() != e.d || disjunctions[i].Goal != e.goal {
+			t.Errorf("FAILED, expected disjunction %d to be %s with goal %t, not %s with goal %t", i, e.d, e.goal, disjunctions[i], disjunctions[i].Goal)
+		}
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	invalids := []struct {
+		s   string
+		err string
+	}{
+		{"", "test:1:1: unexpected end of input"},
+		{"a | 1 | b", "test:1:5: unexpected '1'"},
+		{"( a | b ) &\n( c | 1d )", "test:2:7: unexpected '1'"},
+		{"( a | b ) &", "test:1:12: unexpected end of input"},
+		{"( a | b ) ( c )", "test:1:11: unexpected '('"},
+		{"( a | b", "test:1:8: unexpected end of input"},
+		{"a | | b", "test:1:5: unexpected '|'"},
+		{"a !", "test:1:3: unexpected '!'"},
+		{"a | b )", "test:1:7: unexpected ')'"},
+		{"a!", "test:1:2: unexpected '!'"},
+		{"# only a comment\n\n", "test:3:1: unexpected end of input"},
+		{"a |\n\n# b\n", "test:4:1: unexpected end of input"},
+		{"a / b", "test:1:3: unexpected '/'"},
+		{"a &\n& b", "test:2:1: unexpected '&'"},
+		{"goal: goal: a", "test:1:11: unexpected ':'"},
+		{"a: b", "test:1:2: unexpected ':'"},
+	}
+
+	for _, i := range invalids {
+		_, err := Parse("test", i.s)
+		if err == nil {
+			t.Errorf("FAILED, expected error for %q", i.s)
+			continue
+		}
+		if err.Error() != i.err {
+			t.Errorf("FAILED, expected error %q for %q, not %q", i.err, i.s, err.Error())
+		}
+	}
+}
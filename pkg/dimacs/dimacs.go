@@ -0,0 +1,141 @@
+package dimacs
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/lukaskurz/rebyre/pkg/disjunction"
+	"github.com/lukaskurz/rebyre/pkg/literal"
+)
+
+// VariableName returns the name used for the DIMACS variable with the given index
+func VariableName(index int) string {
+	return fmt.Sprintf("x%d", index)
+}
+
+// Read parses a formula in DIMACS CNF format.
+//
+// Example:
+//
+//	c a comment
+//	p cnf 3 2
+//	1 -2 3 0
+//	-1 2 0
+//
+// Variable n is named as returned by VariableName(n).
+func Read(r io.Reader) ([]*disjunction.Disjunction, error) {
+	scanner := bufio.NewScanner(r)
+
+	variables, clauses := -1, -1
+	disjunctions := make([]*disjunction.Disjunction, 0)
+	current := make([]*literal.Literal, 0)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+
+		if len(text) == 0 || text[0] == 'c' {
+			continue
+		}
+		// some benchmark collections mark the end of the formula with a percent sign
+		if text[0] == '%' {
+			break
+		}
+
+		fields := strings.Fields(text)
+		if fields[0] == "p" {
+			if variables >= 0 {
+				return nil, fmt.Errorf("line %d: duplicate problem line", line)
+			}
+			if len(fields) != 4 || fields[1] != "cnf" {
+				return nil, fmt.Errorf("line %d: problem line has to be \"p cnf <variables> <clauses>\"", line)
+			}
+
+			var err error
+			variables, err = strconv.Atoi(fields[2])
+			if err != nil || variables < 0 {
+				return nil, fmt.Errorf("line %d: invalid number of variables \"%s\"", line, fields[2])
+			}
+			clauses, err = strconv.Atoi(fields[3])
+			if err != nil || clauses < 0 {
+				return nil, fmt.Errorf("line %d: invalid number of clauses \"%s\"", line, fields[3])
+			}
+			continue
+		}
+
+		if variables < 0 {
+			return nil, fmt.Errorf("line %d: clause before problem line", line)
+		}
+
+		for _, f := range fields {
+			n, err := strconv.Atoi(f)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid literal \"%s\"", line, f)
+			}
+
+			if n == 0 {
+				disjunctions = append(disjunctions, disjunction.New(current...))
+				current = current[:0]
+				continue
+			}
+
+			index := n
+			if index < 0 {
+				index = -index
+			}
+			if index > variables {
+				return nil, fmt.Errorf("line %d: variable %d exceeds the declared %d variables", line, index, variables)
+			}
+
+			current = append(current, literal.New(VariableName(index), n < 0))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if variables < 0 {
+		return nil, fmt.Errorf("missing problem line \"p cnf <variables> <clauses>\"")
+	}
+	if len(current) > 0 {
+		return nil, fmt.Errorf("line %d: last clause is not terminated by 0", line)
+	}
+	if len(disjunctions) != clauses {
+		return nil, fmt.Errorf("problem line declares %d clauses, but %d were found", clauses, len(disjunctions))
+	}
+
+	return disjunctions, nil
+}
+
+// Write prints the disjunctions in DIMACS CNF format.
+// Variables are numbered by their first appearance, a comment block at the
+// top maps every variable name to its number.
+func Write(w io.Writer, disjunctions []*disjunction.Disjunction) error {
+	variables := disjunction.Variables(disjunctions)
+	index := make(map[string]int, len(variables))
+
+	b := &strings.Builder{}
+	b.WriteString("c variables:\n")
+	for i, v := range variables {
+		index[v] = i + 1
+		fmt.Fprintf(b, "c %d %s\n", i+1, v)
+	}
+
+	fmt.Fprintf(b, "p cnf %d %d\n", len(variables), len(disjunctions))
+	for _, d := range disjunctions {
+		for _, l := range d.Literals() {
+			if l.Negated() {
+				b.WriteString("-")
+			}
+			fmt.Fprintf(b, "%d ", index[l.Variable()])
+		}
+		b.WriteString("0\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
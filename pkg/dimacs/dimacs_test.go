@@ -0,0 +1,108 @@
+package dimacs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lukaskurz/rebyre/pkg/disjunction"
+)
+
+func TestRead(t *testing.T) {
+	input := `c example from the format description
+c
+p cnf 3 3
+1 -2 3 0
+-1 2
+0
+3 0
+`
+
+	disjunctions, err := Read(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("FAILED, got an error: %s", err.Error())
+	}
+
+	expected := []string{
+		"( x1 | !x2 | x3 )",
+		"( !x1 | x2 )",
+		"( x3 )",
+	}
+
+	if len(disjunctions) != len(expected) {
+		t.Fatalf("FAILED, expected %d disjunctions, not %d", len(expected), len(disjunctions))
+	}
+	for i, e := range expected {
+		if disjunctions[i].String() != e {
+			t.Errorf("FAILED, expected disjunctions[%d] to be %s, not %s", i, e, disjunctions[i])
+		}
+	}
+}
+
+func TestReadPercentEnd(t *testing.T) {
+	input := "p cnf 2 1\n1 -2 0\n%\n0\n"
+
+	disjunctions, err := Read(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("FAILED, got an error: %s", err.Error())
+	}
+	if len(disjunctions) != 1 {
+		t.Errorf("FAILED, expected 1 disjunction, not %d", len(disjunctions))
+	}
+}
+
+func TestReadInvalid(t *testing.T) {
+	invalids := []string{
+		"1 2 0\n",
+		"p cnf 2\n1 2 0\n",
+		"p dnf 2 1\n1 2 0\n",
+		"p cnf 2 1\np cnf 2 1\n1 2 0\n",
+		"p cnf 2 1\n1 a 0\n",
+		"p cnf 2 1\n1 3 0\n",
+		"p cnf 2 1\n1 2\n",
+		"p cnf 2 2\n1 2 0\n",
+		"",
+	}
+
+	for _, i := range invalids {
+		_, err := Read(strings.NewReader(i))
+		if err == nil {
+			t.Errorf("FAILED, expected error for %q", i)
+		}
+	}
+}
+
+func TestWrite(t *testing.T) {
+	d0, _ := disjunction.DisjunctionFromString("( x | !d | !a )")
+	d1, _ := disjunction.DisjunctionFromString("( b )")
+	d2, _ := disjunction.DisjunctionFromString("( !b | a )")
+
+	b := &strings.Builder{}
+	err := Write(b, []*disjunction.Disjunction{d0, d1, d2})
+	if err != nil {
+		t.Fatalf("FAILED, got an error: %s", err.Error())
+	}
+
+	expected := `c variables:
+c 1 x
+c 2 d
+c 3 a
+c 4 b
+p cnf 4 3
+1 -2 -3 0
+4 0
+-4 3 0
+`
+	if b.String() != expected {
+		t.Errorf("FAILED, expected output\n%s\nnot\n%s", expected, b.String())
+	}
+
+	t.Run("read back", func(t *testing.T) {
+		disjunctions, err := Read(strings.NewReader(b.String()))
+		if err != nil {
+			t.Fatalf("FAILED, got an error: %s", err.Error())
+		}
+		if len(disjunctions) != 3 || disjunctions[0].String() != "( x1 | !x2 | !x3 )" {
+			t.Errorf("FAILED, expected the written clauses to be read back")
+		}
+	})
+}
@@ -1,8 +1,8 @@
 package disjunction
 
 import (
-	"math"
 	"regexp"
+	"strings"
 
 	"github.com/lukaskurz/rebyre/pkg/literal"
 )
@@ -13,13 +13,44 @@ type Disjunction struct {
 	literals []*literal.Literal
 	SourceA  int
 	SourceB  int
+	// Extra holds the ids of further sources of a disjunction derived from more
+	// than two disjunctions at once, as by hyperresolution
+	Extra []int
+	// Goal marks disjunctions of the set of support, i.e. the negated goal of a proof
+	// and everything derived from it
+	Goal bool
 }
 
-// ID returns the id of this disjunction
+// New initializes a new Disjunction object containing the provided literals.
+// Its id is assigned once it is added to a Store.
+func New(literals ...*literal.Literal) *Disjunction {
+	return &Disjunction{
+		literals: append([]*literal.Literal{}, literals...),
+	}
+}
+
+// ID returns the id of this disjunction, which is 0 until it is added to a Store
 func (d *Disjunction) ID() int {
 	return d.id
 }
 
+// Parents returns the ids of all disjunctions this one was derived from,
+// SourceA and SourceB first. It is empty for an input disjunction.
+func (d *Disjunction) Parents() []int {
+	parents := make([]int, 0, 2+len(d.Extra))
+	for _, id := range append([]int{d.SourceA, d.SourceB}, d.Extra...) {
+		if id != 0 {
+			parents = append(parents, id)
+		}
+	}
+	return parents
+}
+
+// Literals returns a copy of the literals of this disjunction
+func (d *Disjunction) Literals() []*literal.Literal {
+	return append([]*literal.Literal{}, d.literals...)
+}
+
 // Length outputs the length or the "order" of the disjunction
 func (d *Disjunction) Length() int {
 	return len(d.literals)
@@ -30,6 +61,32 @@ func (d *Disjunction) IsEmpty() bool {
 	return len(d.literals) == 0
 }
 
+// IsTautology checks wether this disjunction contains a literal together with its negation,
+// which makes it true under every assignment
+//
+// Example: "a or b or !a"
+func (d *Disjunction) IsTautology() bool {
+	for i, l1 := range d.literals {
+		for _, l2 := range d.literals[i+1:] {
+			if l1.Opposes(l2) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Satisfied checks wether at least one literal is true under the assignment.
+// Variables missing from the assignment are false.
+func (d *Disjunction) Satisfied(assignment map[string]bool) bool {
+	for _, l := range d.literals {
+		if assignment[l.Variable()] != l.Negated() {
+			return true
+		}
+	}
+	return false
+}
+
 // String stringifies the disjunction.
 //
 // Example: "(!a | b | c)"
@@ -50,30 +107,25 @@ func (d *Disjunction) String() string {
 	return text + " )"
 }
 
-// CompatibleWith checks wether this conjunction is compatible with another in terms of the resolution process.
-// It does this by searching for exactly 1 opposing literal and as many as possible matching literals
+// CompatibleWith checks wether this disjunction is compatible with another in terms of the resolution process.
+// It does this by searching for exactly 1 variable that appears with opposing signs in both disjunctions.
+// Resolving on one of several such variables would only yield a tautology, so these pairs are rejected.
 func (d *Disjunction) CompatibleWith(other *Disjunction) bool {
-	matches := 0
-	opposed := 0
+	opposed := map[string]bool{}
 
 	for _, l1 := range d.literals {
 		for _, l2 := range other.literals {
-			if l1.Equals(l2) {
-				matches++
-				break
-			}
 			if l1.Opposes(l2) {
-				opposed++
-				break
+				opposed[l1.Variable()] = true
 			}
 		}
 	}
 
-	minLength := int(math.Min(float64(d.Length()), float64(other.Length())))
-	return opposed == 1 && matches >= minLength-2
+	return len(opposed) == 1
 }
 
-// Derive derives a disjunction by applying the absorption rule
+// Derive derives a disjunction by applying the absorption rule.
+// The derivation has no id until it is added to a Store and is a goal if one of its sources is.
 func (d *Disjunction) Derive(other *Disjunction) *Disjunction {
 	var base *Disjunction
 	var target *Disjunction
@@ -86,7 +138,7 @@ func (d *Disjunction) Derive(other *Disjunction) *Disjunction {
 		target = d
 	}
 
-	derivation := &Disjunction{id: getNextID(), literals: make([]*literal.Literal, 0), SourceA: base.id, SourceB: target.id}
+	derivation := &Disjunction{literals: make([]*literal.Literal, 0), SourceA: base.id, SourceB: target.id, Goal: base.Goal || target.Goal}
 
 	var opposer *literal.Literal
 	for _, dl := range base.literals {
@@ -101,8 +153,15 @@ func (d *Disjunction) Derive(other *Disjunction) *Disjunction {
 		}
 	}
 
-	for _, l := range append(base.literals, target.literals...) {
-		if !(l.Equals(opposer) || l.Opposes(opposer)) {
+	// both literal slices may be shared with other derivations,
+	// so they are only read and never appended to
+	for _, l := range base.literals {
+		if !l.Equals(opposer) {
+			derivation.literals = append(derivation.literals, l)
+		}
+	}
+	for _, l := range target.literals {
+		if !l.Opposes(opposer) {
 			derivation.literals = append(derivation.literals, l)
 		}
 	}
@@ -148,9 +207,21 @@ func (d *Disjunction) Equals(other *Disjunction) bool {
 	if d.Length() != other.Length() {
 		return false
 	}
-	found := false
+	return d.containedIn(other) && other.containedIn(d)
+}
+
+// Subsumes checks if every literal of this disjunction is contained in the other,
+// which makes the other disjunction redundant
+//
+// Example: "a or b" subsumes "a or b or c"
+func (d *Disjunction) Subsumes(other *Disjunction) bool {
+	return d.containedIn(other)
+}
+
+// containedIn checks if every literal of this disjunction is found in the other
+func (d *Disjunction) containedIn(other *Disjunction) bool {
 	for _, l1 := range d.literals {
-		found = false
+		found := false
 		for _, l2 := range other.literals {
 			if l1.Equals(l2) {
 				found = true
@@ -163,13 +234,32 @@ func (d *Disjunction) Equals(other *Disjunction) bool {
 			return false
 		}
 	}
-	return found
+	return true
+}
+
+// Variables returns the variables of all disjunctions, ordered by their first appearance
+func Variables(disjunctions []*Disjunction) []string {
+	seen := map[string]bool{}
+	variables := make([]string, 0)
+
+	for _, d := range disjunctions {
+		for _, l := range d.literals {
+			if !seen[l.Variable()] {
+				seen[l.Variable()] = true
+				variables = append(variables, l.Variable())
+			}
+		}
+	}
+
+	return variables
 }
 
 // DisjunctionFromString parses a disjunction and the enclosed literals from a string
 // Disjunction has to be written in this way:
 //
 // (a | !!b | !c)
+//
+// Every literal between the "|" signs has to be valid, anything else is an error.
 func DisjunctionFromString(text string) (*Disjunction, error) {
 	r, err := regexp.Compile("[\\s()]") // match all whitespaces and brackets
 	if err != nil {
@@ -177,22 +267,20 @@ func DisjunctionFromString(text string) (*Disjunction, error) {
 	}
 
 	text = r.ReplaceAllString(text, "")
-	rLit, err := regexp.Compile(literal.LiteralMatchExp)
-	if err != nil {
-		return nil, err
+	if len(text) == 0 {
+		return &Disjunction{literals: make([]*literal.Literal, 0)}, nil
 	}
 
-	matches := rLit.FindAllString(text, -1)
-	literals := make([]*literal.Literal, len(matches))
-	for i, m := range matches {
-		literals[i], err = literal.LiteralFromString(m)
+	parts := strings.Split(text, "|")
+	literals := make([]*literal.Literal, len(parts))
+	for i, p := range parts {
+		literals[i], err = literal.LiteralFromString(p)
 		if err != nil {
 			return nil, err
 		}
 	}
 
 	return &Disjunction{
-		id:       getNextID(),
 		literals: literals,
 	}, nil
 }
@@ -1,6 +1,7 @@
 package disjunction
 
 import (
+	"sync"
 	"testing"
 
 	"github.com/lukaskurz/rebyre/pkg/literal"
@@ -234,6 +235,22 @@ func TestDisjunctionCompatibleWith(t *testing.T) {
 			t.Errorf("FAILED, expected compatability of disjunction[1]&[%d] to be %t, not %t", i, results1[i], e)
 		}
 	}
+	t.Run("few shared literals", func(t *testing.T) {
+		pairs := [][2]string{
+			{"a | b | c", "!a | d | e"},
+			{"a | b | c | d", "!d"},
+			{"a | a", "!a | !a | b"},
+			{"a | !a | b", "!a | c"},
+		}
+
+		for _, p := range pairs {
+			d, _ := DisjunctionFromString(p[0])
+			other, _ := DisjunctionFromString(p[1])
+			if !d.CompatibleWith(other) || !other.CompatibleWith(d) {
+				t.Errorf("FAILED, expected \"%s\" and \"%s\" to be compatible", p[0], p[1])
+			}
+		}
+	})
 }
 
 func TestDisjunctionDerive(t *testing.T) {
@@ -250,6 +267,46 @@ func TestDisjunctionDerive(t *testing.T) {
 			t.Errorf("FAILED, derivation[%d] is not correct", i)
 		}
 	}
+	t.Run("goal", func(t *testing.T) {
+		goal := New(literal.New("a", true))
+		goal.Goal = true
+
+		if !sources[3].Derive(goal).Goal || !goal.Derive(sources[3]).Goal {
+			t.Errorf("FAILED, expected derivation from a goal to be a goal")
+		}
+		if sources[3].Derive(sources[4]).Goal {
+			t.Errorf("FAILED, expected derivation without goal not to be a goal")
+		}
+	})
+
+	t.Run("tautological source", func(t *testing.T) {
+		d, _ := DisjunctionFromString("a | !a | b")
+		other, _ := DisjunctionFromString("!a | c")
+		expected, _ := DisjunctionFromString("!a | b | c")
+
+		if !d.Derive(other).Equals(expected) || !other.Derive(d).Equals(expected) {
+			t.Errorf("FAILED, expected derivation to be %s", expected)
+		}
+	})
+}
+
+func TestDisjunctionParents(t *testing.T) {
+	input := New(literal.New("a", false))
+	if len(input.Parents()) != 0 {
+		t.Errorf("FAILED, expected an input disjunction to have no parents, got %v", input.Parents())
+	}
+
+	derived := &Disjunction{SourceA: 3, SourceB: 1, Extra: []int{4, 2}}
+	parents := derived.Parents()
+	expected := []int{3, 1, 4, 2}
+	if len(parents) != len(expected) {
+		t.Fatalf("FAILED, expected parents %v, got %v", expected, parents)
+	}
+	for i := range expected {
+		if parents[i] != expected[i] {
+			t.Errorf("FAILED, expected parents %v, got %v", expected, parents)
+		}
+	}
 }
 
 func TestDisjunctionSanitize(t *testing.T) {
@@ -299,20 +356,64 @@ func TestDisjunctionEquals(t *testing.T) {
 	if d0[1].Equals(d1[1]) {
 		t.Errorf("FAILED, expected d0[1] and d1[1] not to be equal")
 	}
-}
-
-func TestGetNextID(t *testing.T) {
-	idCounter = 0
-	id1 := getNextID()
-	id2 := getNextID()
-	if id1 != 1 {
-		t.Errorf("FAILED, expected id1 to be %d not %d", 1, id1)
+	if !d0[3].Equals(New()) {
+		t.Errorf("FAILED, expected empty disjunctions to be equal")
 	}
-	if id2 != 2 {
-		t.Errorf("FAILED, expected id2 to be %d not %d", 2, id2)
+
+	aa, _ := DisjunctionFromString("a | a")
+	ab, _ := DisjunctionFromString("a | b")
+	if aa.Equals(ab) || ab.Equals(aa) {
+		t.Errorf("FAILED, expected \"a | a\" and \"a | b\" not to be equal")
 	}
 }
 
+func TestStore(t *testing.T) {
+	t.Run("ids start at 1 per store", func(t *testing.T) {
+		for _, store := range []*Store{NewStore(), NewStore()} {
+			d1 := store.Add(New(literal.New("a", false)))
+			d2 := store.Add(New(literal.New("b", false)))
+			if d1.ID() != 1 {
+				t.Errorf("FAILED, expected d1 to have id %d not %d", 1, d1.ID())
+			}
+			if d2.ID() != 2 {
+				t.Errorf("FAILED, expected d2 to have id %d not %d", 2, d2.ID())
+			}
+			if store.Get(2) != d2 {
+				t.Errorf("FAILED, expected to get d2 for id 2")
+			}
+			if store.Get(3) != nil {
+				t.Errorf("FAILED, expected nil for unknown id 3")
+			}
+		}
+	})
+
+	t.Run("concurrent adds", func(t *testing.T) {
+		store := NewStore()
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					store.Add(New())
+				}
+			}()
+		}
+		wg.Wait()
+
+		seen := map[int]bool{}
+		for _, d := range store.All() {
+			if seen[d.ID()] {
+				t.Errorf("FAILED, id %d was handed out twice", d.ID())
+			}
+			seen[d.ID()] = true
+		}
+		if store.Len() != 800 || !seen[1] || !seen[800] {
+			t.Errorf("FAILED, expected ids 1 to 800, got %d disjunctions", store.Len())
+		}
+	})
+}
+
 func TestDisjunctionFromString(t *testing.T) {
 	sources := []string{
 		"(a | !b | c)",
@@ -331,3 +432,109 @@ func TestDisjunctionFromString(t *testing.T) {
 		}
 	}
 }
+
+func TestDisjunctionIsTautology(t *testing.T) {
+	sources := []string{
+		"a | b | !a",
+		"!b | b",
+		"a | b | c",
+		"a | a",
+		"",
+	}
+
+	results := []bool{
+		true,
+		true,
+		false,
+		false,
+		false,
+	}
+
+	for i, e := range sources {
+		d, err := DisjunctionFromString(e)
+		if err != nil {
+			t.Fatalf("FAILED, got an error with \"%s\": %s", e, err.Error())
+		}
+		if d.IsTautology() != results[i] {
+			t.Errorf("FAILED, expected \"%s\" to be a tautology %t", e, results[i])
+		}
+	}
+}
+
+func TestDisjunctionSatisfied(t *testing.T) {
+	disjunctions := setup()
+	assignment := map[string]bool{"a": false, "b": true}
+
+	satisfied := []bool{
+		disjunctions[0].Satisfied(assignment),
+		disjunctions[1].Satisfied(assignment),
+		disjunctions[2].Satisfied(assignment),
+		disjunctions[3].Satisfied(assignment),
+	}
+
+	results := []bool{
+		false,
+		false,
+		true,
+		false,
+	}
+
+	for i, e := range satisfied {
+		if e != results[i] {
+			t.Errorf("FAILED, expected disjunction[%d] to be satisfied %t, not %t", i, results[i], e)
+		}
+	}
+}
+
+func TestVariables(t *testing.T) {
+	variables := Variables(setup())
+	expected := []string{"a", "b", "c"}
+
+	if len(variables) != len(expected) {
+		t.Fatalf("FAILED, expected %d variables, not %d", len(expected), len(variables))
+	}
+	for i, e := range variables {
+		if e != expected[i] {
+			t.Errorf("FAILED, expected variables[%d] to be %s, not %s", i, expected[i], e)
+		}
+	}
+}
+
+func TestDisjunctionFromStringInvalid(t *testing.T) {
+	invalids := []string{
+		"a | 1 | b",
+		"a | | b",
+		"a & b",
+		"(a | 1b)",
+		"!",
+	}
+
+	for _, i := range invalids {
+		_, err := DisjunctionFromString(i)
+		if err == nil {
+			t.Errorf("FAILED, expected error for \"%s\"", i)
+		}
+	}
+}
+
+func TestDisjunctionSubsumes(t *testing.T) {
+	pairs := []struct {
+		d, other string
+		result   bool
+	}{
+		{"a | b", "a | b | c", true},
+		{"a | b", "b | a", true},
+		{"", "a", true},
+		{"a | b | c", "a | b", false},
+		{"a | !b", "a | b | c", false},
+		{"a", "", false},
+	}
+
+	for _, p := range pairs {
+		d, _ := DisjunctionFromString(p.d)
+		other, _ := DisjunctionFromString(p.other)
+		if d.Subsumes(other) != p.result {
+			t.Errorf("FAILED, expected \"%s\" subsumes \"%s\" to be %t", p.d, p.other, p.result)
+		}
+	}
+}
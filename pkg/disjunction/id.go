@@ -1,6 +0,0 @@
-package disjunction
-
-func getNextID() int {
-	idCounter++
-	return idCounter
-}
@@ -1,8 +0,0 @@
-package disjunction
-
-var idCounter int
-
-func init() {
-	idCounter = 0
-}
-
@@ -0,0 +1,61 @@
+package disjunction
+
+import "sync"
+
+// Store holds the disjunctions of a single problem and hands out their ids.
+// Ids start at 1 for every store, so separate problems never share ids.
+// A Store is safe for concurrent use.
+type Store struct {
+	mu      sync.Mutex
+	clauses []*Disjunction
+	byID    map[int]*Disjunction
+}
+
+// NewStore initializes an empty Store
+func NewStore() *Store {
+	return &Store{
+		clauses: make([]*Disjunction, 0),
+		byID:    map[int]*Disjunction{},
+	}
+}
+
+// Add assigns the next free id to d and keeps it in the store.
+// A disjunction can only belong to one store, adding it twice panics.
+func (s *Store) Add(d *Disjunction) *Disjunction {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if d.id != 0 {
+		panic("disjunction already belongs to a store")
+	}
+
+	d.id = len(s.clauses) + 1
+	s.clauses = append(s.clauses, d)
+	s.byID[d.id] = d
+
+	return d
+}
+
+// Get looks up a disjunction by its id, nil is returned for unknown ids
+func (s *Store) Get(id int) *Disjunction {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.byID[id]
+}
+
+// Len returns the number of disjunctions in the store
+func (s *Store) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.clauses)
+}
+
+// All returns all disjunctions in the order they were added
+func (s *Store) All() []*Disjunction {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]*Disjunction{}, s.clauses...)
+}
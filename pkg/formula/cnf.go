@@ -0,0 +1,199 @@
+package formula
+
+import (
+	"fmt"
+
+	"github.com/lukaskurz/rebyre/pkg/disjunction"
+	"github.com/lukaskurz/rebyre/pkg/literal"
+)
+
+// TseitinPrefix is the name prefix of the variables introduced by Tseitin.
+// It is followed by a number, skipping the names the formula uses itself.
+const TseitinPrefix = "t"
+
+// CNF converts f into an equivalent set of clauses by eliminating implications
+// and equivalences, pushing negations inwards and distributing disjunctions over
+// conjunctions. The result can grow exponentially in the size of f.
+func CNF(f Formula) []*disjunction.Disjunction {
+	clauses := distribute(nnf(f, false))
+
+	disjunctions := make([]*disjunction.Disjunction, len(clauses))
+	for i, c := range clauses {
+		disjunctions[i] = disjunction.New(c...)
+		disjunctions[i].Sanitize()
+	}
+
+	return disjunctions
+}
+
+// nnf transforms f into negation normal form, consisting only of
+// conjunctions, disjunctions and negated or plain variables
+func nnf(f Formula, negated bool) Formula {
+	switch f := f.(type) {
+	case *Var:
+		if negated {
+			return &Not{F: f}
+		}
+		return f
+	case *Not:
+		return nnf(f.F, !negated)
+	case *And:
+		if negated {
+			return &Or{L: nnf(f.L, true), R: nnf(f.R, true)}
+		}
+		return &And{L: nnf(f.L, false), R: nnf(f.R, false)}
+	case *Or:
+		if negated {
+			return &And{L: nnf(f.L, true), R: nnf(f.R, true)}
+		}
+		return &Or{L: nnf(f.L, false), R: nnf(f.R, false)}
+	case *Implies:
+		return nnf(&Or{L: &Not{F: f.L}, R: f.R}, negated)
+	case *Iff:
+		both := &And{L: &Implies{L: f.L, R: f.R}, R: &Implies{L: f.R, R: f.L}}
+		return nnf(both, negated)
+	default:
+		panic(fmt.Sprintf("unknown formula type %T", f))
+	}
+}
+
+// distribute converts a formula in negation normal form into clauses
+func distribute(f Formula) [][]*literal.Literal {
+	switch f := f.(type) {
+	case *Var:
+		return [][]*literal.Literal{{literal.New(f.Name, false)}}
+	case *Not:
+		return [][]*literal.Literal{{literal.New(f.F.(*Var).Name, true)}}
+	case *And:
+		return append(distribute(f.L), distribute(f.R)...)
+	case *Or:
+		left := distribute(f.L)
+		right := distribute(f.R)
+
+		clauses := make([][]*literal.Literal, 0, len(left)*len(right))
+		for _, l := range left {
+			for _, r := range right {
+				clause := make([]*literal.Literal, 0, len(l)+len(r))
+				clause = append(clause, l...)
+				clauses = append(clauses, append(clause, r...))
+			}
+		}
+		return clauses
+	default:
+		panic(fmt.Sprintf("unexpected formula type %T in negation normal form", f))
+	}
+}
+
+// Tseitin converts f into an equisatisfiable set of clauses that grows only
+// linearly in the size of f. Every compound subformula is named by a fresh
+// variable, TseitinPrefix followed by a number, which is defined by clauses
+// stating its equivalence to the subformula.
+func Tseitin(f Formula) []*disjunction.Disjunction {
+	return TseitinEach(nil, f)[0]
+}
+
+// TseitinEach converts each of the formulas like Tseitin and returns their
+// clauses separately. The fresh variables are distinct across all formulas
+// and differ from the taken variables, so the clauses can be combined with
+// each other and with other clauses over the taken variables.
+func TseitinEach(taken []string, fs ...Formula) [][]*disjunction.Disjunction {
+	t := &tseitin{taken: map[string]bool{}}
+	for _, v := range taken {
+		t.taken[v] = true
+	}
+	for _, f := range fs {
+		for _, v := range Variables(f) {
+			t.taken[v] = true
+		}
+	}
+
+	converted := make([][]*disjunction.Disjunction, len(fs))
+	for i, f := range fs {
+		t.clauses = make([]*disjunction.Disjunction, 0)
+		t.add(t.name(f))
+		converted[i] = t.clauses
+	}
+
+	return converted
+}
+
+type tseitin struct {
+	counter int
+	clauses []*disjunction.Disjunction
+	// taken holds the variables of the formula, which fresh variables must not be named like
+	taken map[string]bool
+}
+
+func (t *tseitin) add(literals ...*literal.Literal) {
+	d := disjunction.New(literals...)
+	d.Sanitize()
+	t.clauses = append(t.clauses, d)
+}
+
+func negate(l *literal.Literal) *literal.Literal {
+	return literal.New(l.Variable(), !l.Negated())
+}
+
+// name returns a literal equivalent to f, introducing fresh variables for compound formulas
+func (t *tseitin) name(f Formula) *literal.Literal {
+	switch f := f.(type) {
+	case *Var:
+		return literal.New(f.Name, false)
+	case *Not:
+		return negate(t.name(f.F))
+	}
+
+	var a, b *literal.Literal
+	switch f := f.(type) {
+	case *And:
+		a, b = t.name(f.L), t.name(f.R)
+	case *Or:
+		a, b = t.name(f.L), t.name(f.R)
+	case *Implies:
+		a, b = t.name(f.L), t.name(f.R)
+	case *Iff:
+		a, b = t.name(f.L), t.name(f.R)
+	default:
+		panic(fmt.Sprintf("unknown formula type %T", f))
+	}
+
+	x := literal.New(t.fresh(), false)
+	notX, notA, notB := negate(x), negate(a), negate(b)
+
+	switch f.(type) {
+	case *And:
+		// x <-> a & b
+		t.add(notX, a)
+		t.add(notX, b)
+		t.add(x, notA, notB)
+	case *Or:
+		// x <-> a | b
+		t.add(notX, a, b)
+		t.add(x, notA)
+		t.add(x, notB)
+	case *Implies:
+		// x <-> (a -> b)
+		t.add(notX, notA, b)
+		t.add(x, a)
+		t.add(x, notB)
+	case *Iff:
+		// x <-> (a <-> b)
+		t.add(notX, notA, b)
+		t.add(notX, a, notB)
+		t.add(x, a, b)
+		t.add(x, notA, notB)
+	}
+
+	return x
+}
+
+// fresh returns the next name of a fresh variable that isn't taken
+func (t *tseitin) fresh() string {
+	for {
+		t.counter++
+		name := fmt.Sprintf("%s%d", TseitinPrefix, t.counter)
+		if !t.taken[name] {
+			return name
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package formula
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lukaskurz/rebyre/pkg/disjunction"
+)
+
+var formulas = []string{
+	"a",
+	"!a",
+	"a -> b",
+	"a <-> b",
+	"!(a <-> !b)",
+	"(a & b) | (c & !a)",
+	"!((a -> b) -> a) -> a",
+	"(a | b) & (a -> c) & (b -> c) & !c",
+	"a & !a",
+	"!(a & (b | !c)) <-> (c -> a)",
+}
+
+func satisfied(clauses []*disjunction.Disjunction, assignment map[string]bool) bool {
+	for _, c := range clauses {
+		if !c.Satisfied(assignment) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestCNF(t *testing.T) {
+	for _, text := range formulas {
+		f, err := Parse(text)
+		if err != nil {
+			t.Fatalf("FAILED, got an error with %q: %s", text, err.Error())
+		}
+		clauses := CNF(f)
+
+		variables := disjunction.Variables(clauses)
+		for _, v := range []string{"a", "b", "c"} {
+			variables = append(variables, v)
+		}
+
+		for _, assignment := range assignments(variables) {
+			if Evaluate(f, assignment) != satisfied(clauses, assignment) {
+				t.Errorf("FAILED, CNF of %q is not equivalent under %v", text, assignment)
+				break
+			}
+		}
+	}
+}
+
+func TestTseitin(t *testing.T) {
+	for _, text := range formulas {
+		f, err := Parse(text)
+		if err != nil {
+			t.Fatalf("FAILED, got an error with %q: %s", text, err.Error())
+		}
+		clauses := Tseitin(f)
+
+		original := []string{"a", "b", "c"}
+		fresh := make([]string, 0)
+		for _, v := range disjunction.Variables(clauses) {
+			if strings.HasPrefix(v, TseitinPrefix) {
+				fresh = append(fresh, v)
+			}
+		}
+
+		// every model of f extends to a model of the clauses and
+		// every model of the clauses is a model of f
+		for _, assignment := range assignments(original) {
+			extended := false
+			for _, extension := range assignments(fresh) {
+				for v, value := range assignment {
+					extension[v] = value
+				}
+				if satisfied(clauses, extension) {
+					extended = true
+					break
+				}
+			}
+
+			if Evaluate(f, assignment) != extended {
+				t.Errorf("FAILED, Tseitin clauses of %q are not equisatisfiable under %v", text, assignment)
+				break
+			}
+		}
+	}
+}
+
+func TestTseitinTakenNames(t *testing.T) {
+	f, err := Parse("t1 & t2 | !t3")
+	if err != nil {
+		t.Fatalf("FAILED, got an error: %s", err.Error())
+	}
+
+	clauses := Tseitin(f)
+	original := map[string]bool{"t1": true, "t2": true, "t3": true}
+	fresh := make([]string, 0)
+	for _, v := range disjunction.Variables(clauses) {
+		if !original[v] {
+			fresh = append(fresh, v)
+		}
+	}
+
+	// the fresh variables name the conjunction and the disjunction, so if
+	// one of them reused t1, t2 or t3 there would be fewer than two
+	if len(fresh) != 2 {
+		t.Fatalf("FAILED, expected 2 fresh variables besides t1, t2 and t3, not %v", fresh)
+	}
+	for _, v := range fresh {
+		if v != "t4" && v != "t5" {
+			t.Errorf("FAILED, expected the fresh variables to be t4 and t5, not %s", v)
+		}
+	}
+}
+
+func TestTseitinEach(t *testing.T) {
+	f, _ := Parse("a & b")
+	g, _ := Parse("!(a & b)")
+
+	converted := TseitinEach([]string{"t1"}, f, g)
+	if len(converted) != 2 {
+		t.Fatalf("FAILED, expected the clauses of 2 formulas, not %d", len(converted))
+	}
+
+	// each formula names its conjunction with a fresh variable of its own
+	fresh := map[string]int{}
+	for i, clauses := range converted {
+		for _, v := range disjunction.Variables(clauses) {
+			if v != "a" && v != "b" {
+				fresh[v] = i
+			}
+		}
+	}
+	if len(fresh) != 2 {
+		t.Errorf("FAILED, expected 2 distinct fresh variables, not %v", fresh)
+	}
+	if _, ok := fresh["t1"]; ok {
+		t.Errorf("FAILED, expected the taken variable t1 not to be used")
+	}
+
+	all := append(converted[0], converted[1]...)
+	for _, assignment := range assignments(disjunction.Variables(all)) {
+		if satisfied(all, assignment) {
+			t.Errorf("FAILED, expected the clauses of a formula and its negation to be unsatisfiable, %v satisfies them", assignment)
+			break
+		}
+	}
+}
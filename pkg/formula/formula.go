@@ -0,0 +1,133 @@
+package formula
+
+import "fmt"
+
+// Formula is a formula of propositional logic
+type Formula interface {
+	String() string
+}
+
+// Var is a propositional variable
+type Var struct {
+	Name string
+}
+
+// Not is the negation of a formula
+type Not struct {
+	F Formula
+}
+
+// And is the conjunction of two formulas
+type And struct {
+	L, R Formula
+}
+
+// Or is the disjunction of two formulas
+type Or struct {
+	L, R Formula
+}
+
+// Implies is the implication from L to R
+type Implies struct {
+	L, R Formula
+}
+
+// Iff is the equivalence of two formulas
+type Iff struct {
+	L, R Formula
+}
+
+// String prints the variable name
+func (v *Var) String() string {
+	return v.Name
+}
+
+// String prints the negation.
+//
+// Example: "!(a & b)"
+func (n *Not) String() string {
+	return "!" + n.F.String()
+}
+
+// String prints the conjunction in parentheses.
+//
+// Example: "(a & b)"
+func (a *And) String() string {
+	return fmt.Sprintf("(%s & %s)", a.L, a.R)
+}
+
+// String prints the disjunction in parentheses.
+//
+// Example: "(a | b)"
+func (o *Or) String() string {
+	return fmt.Sprintf("(%s | %s)", o.L, o.R)
+}
+
+// String prints the implication in parentheses.
+//
+// Example: "(a -> b)"
+func (i *Implies) String() string {
+	return fmt.Sprintf("(%s -> %s)", i.L, i.R)
+}
+
+// String prints the equivalence in parentheses.
+//
+// Example: "(a <-> b)"
+func (i *Iff) String() string {
+	return fmt.Sprintf("(%s <-> %s)", i.L, i.R)
+}
+
+// Evaluate computes the truth value of f under the assignment.
+// Variables missing from the assignment are false.
+func Evaluate(f Formula, assignment map[string]bool) bool {
+	switch f := f.(type) {
+	case *Var:
+		return assignment[f.Name]
+	case *Not:
+		return !Evaluate(f.F, assignment)
+	case *And:
+		return Evaluate(f.L, assignment) && Evaluate(f.R, assignment)
+	case *Or:
+		return Evaluate(f.L, assignment) || Evaluate(f.R, assignment)
+	case *Implies:
+		return !Evaluate(f.L, assignment) || Evaluate(f.R, assignment)
+	case *Iff:
+		return Evaluate(f.L, assignment) == Evaluate(f.R, assignment)
+	default:
+		panic(fmt.Sprintf("unknown formula type %T", f))
+	}
+}
+
+// Variables returns the names of all variables of f, ordered by their first appearance
+func Variables(f Formula) []string {
+	seen := map[string]bool{}
+	variables := make([]string, 0)
+
+	var walk func(f Formula)
+	walk = func(f Formula) {
+		switch f := f.(type) {
+		case *Var:
+			if !seen[f.Name] {
+				seen[f.Name] = true
+				variables = append(variables, f.Name)
+			}
+		case *Not:
+			walk(f.F)
+		case *And:
+			walk(f.L)
+			walk(f.R)
+		case *Or:
+			walk(f.L)
+			walk(f.R)
+		case *Implies:
+			walk(f.L)
+			walk(f.R)
+		case *Iff:
+			walk(f.L)
+			walk(f.R)
+		}
+	}
+	walk(f)
+
+	return variables
+}
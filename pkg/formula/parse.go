@@ -0,0 +1,235 @@
+package formula
+
+import (
+	"fmt"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenVar
+	tokenNot
+	tokenAnd
+	tokenOr
+	tokenImplies
+	tokenIff
+	tokenOpen
+	tokenClose
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	line int
+	col  int
+}
+
+// Parse parses a formula of propositional logic.
+// Operators from strongest to weakest binding are "!", "&", "|", "->" and "<->".
+// Implications associate to the right, all other binary operators to the left.
+// Variable names start with one of the letters a-zA-Z, followed by letters and digits.
+//
+// Example: "(a -> b) & !(b <-> !c) | d"
+//
+// Errors carry the position of the offending character, e.g. "1:5: unexpected ')'".
+func Parse(text string) (Formula, error) {
+	tokens, err := tokenize(text)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	f, err := p.parseIff()
+	if err != nil {
+		return nil, err
+	}
+	if next := p.peek(); next.kind != tokenEOF {
+		return nil, next.unexpected()
+	}
+
+	return f, nil
+}
+
+func (t token) unexpected() error {
+	if t.kind == tokenEOF {
+		return fmt.Errorf("%d:%d: unexpected end of input", t.line, t.col)
+	}
+	return fmt.Errorf("%d:%d: unexpected '%s'", t.line, t.col, t.text)
+}
+
+func tokenize(text string) ([]token, error) {
+	runes := []rune(text)
+	tokens := make([]token, 0)
+
+	line, col := 1, 1
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		t := token{line: line, col: col}
+		length := 1
+
+		switch {
+		case r == '\n':
+			line++
+			col = 1
+			i++
+			continue
+		case unicode.IsSpace(r):
+		case isLetter(r):
+			for i+length < len(runes) && (isLetter(runes[i+length]) || isDigit(runes[i+length])) {
+				length++
+			}
+			t.kind = tokenVar
+		case r == '!':
+			t.kind = tokenNot
+		case r == '&':
+			t.kind = tokenAnd
+		case r == '|':
+			t.kind = tokenOr
+		case r == '(':
+			t.kind = tokenOpen
+		case r == ')':
+			t.kind = tokenClose
+		case r == '-' && i+1 < len(runes) && runes[i+1] == '>':
+			t.kind = tokenImplies
+			length = 2
+		case r == '<' && i+2 < len(runes) && runes[i+1] == '-' && runes[i+2] == '>':
+			t.kind = tokenIff
+			length = 3
+		default:
+			return nil, fmt.Errorf("%d:%d: unexpected '%c'", line, col, r)
+		}
+
+		if !unicode.IsSpace(r) {
+			t.text = string(runes[i : i+length])
+			tokens = append(tokens, t)
+		}
+		i += length
+		col += length
+	}
+
+	return append(tokens, token{kind: tokenEOF, line: line, col: col}), nil
+}
+
+func isLetter(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) parseIff() (Formula, error) {
+	left, err := p.parseImplies()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().kind == tokenIff {
+		p.next()
+		right, err := p.parseImplies()
+		if err != nil {
+			return nil, err
+		}
+		left = &Iff{L: left, R: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseImplies() (Formula, error) {
+	left, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.peek().kind == tokenImplies {
+		p.next()
+		right, err := p.parseImplies()
+		if err != nil {
+			return nil, err
+		}
+		return &Implies{L: left, R: right}, nil
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseOr() (Formula, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().kind == tokenOr {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &Or{L: left, R: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Formula, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().kind == tokenAnd {
+		p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &And{L: left, R: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseNot() (Formula, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenNot:
+		f, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &Not{F: f}, nil
+	case tokenVar:
+		return &Var{Name: t.text}, nil
+	case tokenOpen:
+		f, err := p.parseIff()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokenClose {
+			return nil, closing.unexpected()
+		}
+		return f, nil
+	default:
+		return nil, t.unexpected()
+	}
+}
@@ -0,0 +1,106 @@
+package formula
+
+import (
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	valids := []struct {
+		s string
+		f string
+	}{
+		{"a", "a"},
+		{"!!a", "!!a"},
+		{"a & b | c", "((a & b) | c)"},
+		{"a | b & c", "(a | (b & c))"},
+		{"a -> b -> c", "(a -> (b -> c))"},
+		{"a <-> b <-> c", "((a <-> b) <-> c)"},
+		{"a | b -> c <-> d", "(((a | b) -> c) <-> d)"},
+		{"!(a & b)", "!(a & b)"},
+		{"!a & b", "(!a & b)"},
+		{" ( a\n|\tb ) & c ", "((a | b) & c)"},
+		{"x1 -> !x12", "(x1 -> !x12)"},
+	}
+
+	for _, v := range valids {
+		f, err := Parse(v.s)
+		if err != nil {
+			t.Errorf("FAILED, expected no error for %q, got %s", v.s, err.Error())
+			continue
+		}
+		if f.String() != v.f {
+			t.Errorf("FAILED, expected %q to parse as %s, not %s", v.s, v.f, f)
+		}
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	invalids := []struct {
+		s   string
+		err string
+	}{
+		{"", "1:1: unexpected end of input"},
+		{"a &", "1:4: unexpected end of input"},
+		{"a 1", "1:3: unexpected '1'"},
+		{"(a | b", "1:7: unexpected end of input"},
+		{"a | b)", "1:6: unexpected ')'"},
+		{"a\n& - b", "2:3: unexpected '-'"},
+		{"a b", "1:3: unexpected 'b'"},
+		{"a < b", "1:3: unexpected '<'"},
+	}
+
+	for _, i := range invalids {
+		_, err := Parse(i.s)
+		if err == nil {
+			t.Errorf("FAILED, expected error for %q", i.s)
+			continue
+		}
+		if err.Error() != i.err {
+			t.Errorf("FAILED, expected error %q for %q, not %q", i.err, i.s, err.Error())
+		}
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	f, err := Parse("(a -> b) <-> (!a | b)")
+	if err != nil {
+		t.Fatalf("FAILED, got an error: %s", err.Error())
+	}
+
+	for _, assignment := range assignments([]string{"a", "b"}) {
+		if !Evaluate(f, assignment) {
+			t.Errorf("FAILED, expected tautology to be true under %v", assignment)
+		}
+	}
+}
+
+func TestVariables(t *testing.T) {
+	f, err := Parse("(b -> a) & !(c | b)")
+	if err != nil {
+		t.Fatalf("FAILED, got an error: %s", err.Error())
+	}
+
+	variables := Variables(f)
+	expected := []string{"b", "a", "c"}
+	if len(variables) != len(expected) {
+		t.Fatalf("FAILED, expected %d variables, not %d", len(expected), len(variables))
+	}
+	for i, e := range variables {
+		if e != expected[i] {
+			t.Errorf("FAILED, expected variables[%d] to be %s, not %s", i, expected[i], e)
+		}
+	}
+}
+
+// assignments enumerates every assignment of the variables
+func assignments(variables []string) []map[string]bool {
+	all := make([]map[string]bool, 0, 1<<uint(len(variables)))
+	for bits := 0; bits < 1<<uint(len(variables)); bits++ {
+		a := map[string]bool{}
+		for i, v := range variables {
+			a[v] = bits&(1<<uint(i)) != 0
+		}
+		all = append(all, a)
+	}
+	return all
+}
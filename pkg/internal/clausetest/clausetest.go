@@ -0,0 +1,91 @@
+// Package clausetest provides the helpers the tests of the solvers share: parsing
+// clauses, generating random clause sets and deciding them by brute force.
+package clausetest
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/lukaskurz/rebyre/pkg/disjunction"
+	"github.com/lukaskurz/rebyre/pkg/literal"
+)
+
+// Parse reads every text as a clause and fails the test if one can't be read
+func Parse(t testing.TB, texts ...string) []*disjunction.Disjunction {
+	t.Helper()
+	clauses := make([]*disjunction.Disjunction, len(texts))
+	for i, text := range texts {
+		var err error
+		clauses[i], err = disjunction.DisjunctionFromString(text)
+		if err != nil {
+			t.Fatalf("FAILED, got an error with \"%s\": %s", text, err.Error())
+		}
+	}
+	return clauses
+}
+
+// Generator creates random clause sets, e.g. for the Generate method of a type
+// checked with testing/quick
+type Generator struct {
+	Clauses   int  // the maximal number of clauses, at least one is generated
+	Width     int  // the maximal number of literals of a clause
+	Variables int  // the number of variables, named a, b, c, ...
+	Empty     bool // whether a clause may have no literals at all
+}
+
+// Generate returns a random clause set within the bounds of the generator
+func (g Generator) Generate(r *rand.Rand) []*disjunction.Disjunction {
+	clauses := make([]*disjunction.Disjunction, 1+r.Intn(g.Clauses))
+	for i := range clauses {
+		width := 1 + r.Intn(g.Width)
+		if g.Empty {
+			width = r.Intn(g.Width + 1)
+		}
+
+		literals := make([]*literal.Literal, width)
+		for j := range literals {
+			literals[j] = literal.New(string(rune('a'+r.Intn(g.Variables))), r.Intn(2) == 0)
+		}
+		clauses[i] = disjunction.New(literals...)
+	}
+	return clauses
+}
+
+// Satisfies checks if the model satisfies every clause
+func Satisfies(model map[string]bool, clauses []*disjunction.Disjunction) bool {
+	for _, c := range clauses {
+		if !c.Satisfied(model) {
+			return false
+		}
+	}
+	return true
+}
+
+// Satisfiable decides the clauses by evaluating every assignment
+func Satisfiable(clauses []*disjunction.Disjunction) bool {
+	return !forAll(disjunction.Variables(clauses), func(model map[string]bool) bool {
+		return !Satisfies(model, clauses)
+	})
+}
+
+// Entails checks that every assignment satisfying the premises satisfies the conclusion
+func Entails(premises []*disjunction.Disjunction, conclusion *disjunction.Disjunction) bool {
+	variables := disjunction.Variables(append([]*disjunction.Disjunction{conclusion}, premises...))
+	return forAll(variables, func(model map[string]bool) bool {
+		return !Satisfies(model, premises) || conclusion.Satisfied(model)
+	})
+}
+
+// forAll checks if every assignment of the variables passes the check
+func forAll(variables []string, check func(model map[string]bool) bool) bool {
+	for bits := 0; bits < 1<<uint(len(variables)); bits++ {
+		model := map[string]bool{}
+		for i, v := range variables {
+			model[v] = bits&(1<<uint(i)) != 0
+		}
+		if !check(model) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,57 @@
+package clausetest
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestSatisfiable(t *testing.T) {
+	tests := []struct {
+		clauses     []string
+		satisfiable bool
+	}{
+		{[]string{"a"}, true},
+		{[]string{"a", "!a"}, false},
+		{[]string{"a | b", "!a | b", "a | !b", "!a | !b"}, false},
+		{[]string{"a | b", "!a | b", "a | !b"}, true},
+	}
+
+	for _, test := range tests {
+		if Satisfiable(Parse(t, test.clauses...)) != test.satisfiable {
+			t.Errorf("FAILED, expected %v to be satisfiable %t", test.clauses, test.satisfiable)
+		}
+	}
+}
+
+func TestEntails(t *testing.T) {
+	premises := Parse(t, "!a | b", "!b | c")
+
+	if !Entails(premises, Parse(t, "!a | c")[0]) {
+		t.Errorf("FAILED, expected ( !a | c ) to be entailed")
+	}
+	if Entails(premises, Parse(t, "a | c")[0]) {
+		t.Errorf("FAILED, expected ( a | c ) not to be entailed")
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	g := Generator{Clauses: 5, Width: 3, Variables: 2}
+
+	for i := 0; i < 100; i++ {
+		clauses := g.Generate(r)
+		if len(clauses) < 1 || len(clauses) > g.Clauses {
+			t.Fatalf("FAILED, expected 1 to %d clauses, got %d", g.Clauses, len(clauses))
+		}
+		for _, c := range clauses {
+			if c.Length() < 1 || c.Length() > g.Width {
+				t.Errorf("FAILED, expected 1 to %d literals, got %s", g.Width, c)
+			}
+			for _, l := range c.Literals() {
+				if l.Variable() != "a" && l.Variable() != "b" {
+					t.Errorf("FAILED, expected only the variables a and b, got %s", c)
+				}
+			}
+		}
+	}
+}
@@ -7,10 +7,10 @@ import (
 )
 
 // LiteralMatchExp is a regexp to match a single literal
-const LiteralMatchExp = "!*[a-zA-Z]+"
+const LiteralMatchExp = "!*[a-zA-Z][a-zA-Z0-9]*"
 
 // LiteralParseExp is a regexp to match components of a literal
-const LiteralParseExp = "([!]*)([a-zA-Z]+)"
+const LiteralParseExp = "([!]*)([a-zA-Z][a-zA-Z0-9]*)"
 
 // Literal is struct to contain a SAT literal
 type Literal struct {
@@ -58,7 +58,7 @@ func LiteralFromString(text string) (*Literal, error) {
 	}
 
 	// Match a text optionally preceded by negation signs and ending in a variable.
-	// Only a-zA-Z for variable name allowed
+	// A variable name starts with a letter a-zA-Z, digits may follow
 	//
 	// Example: !!a or !a or b
 	//
@@ -147,12 +147,8 @@ func TestLiteralFromString(t *testing.T) {
 			"!",
 			"1",
 			"1asd",
-			"as1",
-			"aaa1aa",
 			"!1",
-			"!a1",
 			"!1a",
-			"!a1a",
 			"!1asdasd1",
 			"a!",
 			"asfsf!",
@@ -176,6 +172,9 @@ func TestLiteralFromString(t *testing.T) {
 			{"!myth", &Literal{variable: "myth", negated: true}},
 			{"!!a", &Literal{variable: "a", negated: false}},
 			{"!a", &Literal{variable: "a", negated: true}},
+			{"as1", &Literal{variable: "as1", negated: false}},
+			{"aaa1aa", &Literal{variable: "aaa1aa", negated: false}},
+			{"!a1", &Literal{variable: "a1", negated: true}},
 		}
 
 		for _, i := range valids {
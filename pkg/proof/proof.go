@@ -0,0 +1,200 @@
+// Package proof writes refutations by resolution in a readable layout: as a tree
+// of the clauses each clause was derived from, as the same tree printing every
+// derived clause once, as a chain of resolvents or as a Graphviz graph.
+package proof
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/lukaskurz/rebyre/pkg/disjunction"
+)
+
+// Derivations looks up the clauses of a proof by their ids
+type Derivations interface {
+	Clause(id int) *disjunction.Disjunction
+}
+
+// WriteTree writes d and the clauses it was derived from as a tree. The first
+// source continues the line after a "T", the others follow on the lines below,
+// marked by "├" and by "└" for the last one. A clause used several times is
+// written with its whole derivation each time.
+//
+// Example:
+//
+//	(  )T( a )
+//	    └( !a )
+func WriteTree(w io.Writer, proof Derivations, d *disjunction.Disjunction) error {
+	b := &strings.Builder{}
+	writeTree(b, proof, d, "", "", nil)
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteDAG writes the derivation of d like WriteTree, but prefixes a derived
+// clause with its id the first time and only refers to it as "[#id]" after that.
+func WriteDAG(w io.Writer, proof Derivations, d *disjunction.Disjunction) error {
+	b := &strings.Builder{}
+	writeTree(b, proof, d, "", "", map[int]bool{})
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeTree writes d below the given indent, after the branch connecting it to
+// the clause derived from it. Unless printed is nil, derived clauses that were
+// written already are only referred to.
+func writeTree(b *strings.Builder, proof Derivations, d *disjunction.Disjunction, indent string, branch string, printed map[int]bool) {
+	text := d.String()
+	parents := d.Parents()
+	if printed != nil && len(parents) > 0 {
+		if printed[d.ID()] {
+			text = fmt.Sprintf("[#%d]", d.ID())
+			parents = nil
+		} else {
+			printed[d.ID()] = true
+			text = fmt.Sprintf("#%d %s", d.ID(), text)
+		}
+	}
+
+	if len(indent) > 0 {
+		if branch == "T" {
+			b.WriteString("T")
+		} else {
+			b.WriteString(indent + branch)
+		}
+	}
+	b.WriteString(text)
+
+	// the line of a "├" continues below it, down to its next sibling
+	nextIndent := indent
+	switch branch {
+	case "├":
+		nextIndent += "|"
+	case "└":
+		nextIndent += " "
+	}
+	for i := 0; i < len(text); i++ {
+		nextIndent += " "
+	}
+
+	for i, id := range parents {
+		next := proof.Clause(id)
+		switch {
+		case i == 0:
+			writeTree(b, proof, next, nextIndent+"|", "T", printed)
+		case i < len(parents)-1:
+			writeTree(b, proof, next, nextIndent, "├", printed)
+		default:
+			writeTree(b, proof, next, nextIndent, "└", printed)
+		}
+	}
+	if len(parents) == 0 {
+		b.WriteString("\n")
+	}
+}
+
+// WriteChain writes a linear refutation from the top clause down to the empty
+// clause d, with the side clause of each step indented below its center clause.
+// The center clause of a step is SourceA of the resolvent, the side clause SourceB.
+//
+// Example:
+//
+//	( a | b )
+//	  | ( !a | b )
+//	( b )
+//	  | ( !b )
+//	(  )
+func WriteChain(w io.Writer, proof Derivations, d *disjunction.Disjunction) error {
+	chain := []*disjunction.Disjunction{d}
+	for d.SourceA != 0 {
+		d = proof.Clause(d.SourceA)
+		chain = append(chain, d)
+	}
+
+	b := &strings.Builder{}
+	ancestors := map[int]bool{}
+	for i := len(chain) - 1; i >= 0; i-- {
+		if i < len(chain)-1 {
+			side := proof.Clause(chain[i].SourceB)
+			if ancestors[side.ID()] {
+				fmt.Fprintf(b, "  | %s ancestor\n", side)
+			} else {
+				fmt.Fprintf(b, "  | %s\n", side)
+			}
+		}
+		fmt.Fprintf(b, "%s\n", chain[i])
+		ancestors[chain[i].ID()] = true
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// IsChain reports if d is derived by a chain, in which the side clause of every
+// step is an input clause or an earlier clause of the chain. Only then WriteChain
+// shows the whole derivation of d.
+func IsChain(proof Derivations, d *disjunction.Disjunction) bool {
+	chain := []*disjunction.Disjunction{d}
+	for d.SourceA != 0 {
+		if len(d.Extra) > 0 {
+			return false
+		}
+		d = proof.Clause(d.SourceA)
+		chain = append(chain, d)
+	}
+
+	ancestors := map[int]bool{}
+	for i := len(chain) - 1; i >= 0; i-- {
+		if i < len(chain)-1 {
+			side := proof.Clause(chain[i].SourceB)
+			if len(side.Parents()) > 0 && !ancestors[side.ID()] {
+				return false
+			}
+		}
+		ancestors[chain[i].ID()] = true
+	}
+	return true
+}
+
+// WriteDot writes the proofs of the refutations as a Graphviz graph, with an
+// edge from each clause to the clauses derived from it. Every clause is a single
+// node, however often it is used. Input clauses are boxes, derived clauses
+// ellipses and empty clauses are highlighted.
+func WriteDot(w io.Writer, proof Derivations, refutations []*disjunction.Disjunction) error {
+	b := &strings.Builder{}
+	b.WriteString("digraph proof {\n")
+
+	visited := map[int]bool{}
+	var visit func(d *disjunction.Disjunction)
+	visit = func(d *disjunction.Disjunction) {
+		if visited[d.ID()] {
+			return
+		}
+		visited[d.ID()] = true
+
+		parents := d.Parents()
+		style := "shape=ellipse"
+		switch {
+		case d.IsEmpty():
+			style = "shape=doubleoctagon, style=filled, fillcolor=lightgrey"
+		case len(parents) == 0:
+			style = "shape=box"
+		}
+		fmt.Fprintf(b, "\tc%d [label=%q, %s];\n", d.ID(), d.String(), style)
+
+		for _, id := range parents {
+			fmt.Fprintf(b, "\tc%d -> c%d;\n", id, d.ID())
+		}
+		for _, id := range parents {
+			visit(proof.Clause(id))
+		}
+	}
+	for _, e := range refutations {
+		visit(e)
+	}
+
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
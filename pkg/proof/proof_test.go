@@ -0,0 +1,165 @@
+package proof
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lukaskurz/rebyre/pkg/disjunction"
+)
+
+// store looks up the clauses of a proof built by the tests
+type store struct {
+	*disjunction.Store
+}
+
+func (s store) Clause(id int) *disjunction.Disjunction {
+	return s.Get(id)
+}
+
+func parse(t *testing.T, s store, texts ...string) []*disjunction.Disjunction {
+	clauses := make([]*disjunction.Disjunction, len(texts))
+	for i, text := range texts {
+		d, err := disjunction.DisjunctionFromString(text)
+		if err != nil {
+			t.Fatalf("FAILED, got an error with \"%s\": %s", text, err.Error())
+		}
+		clauses[i] = s.Add(d)
+	}
+	return clauses
+}
+
+// lemma returns a refutation that uses the lemma ( b ) twice
+func lemma(t *testing.T) (store, *disjunction.Disjunction) {
+	s := store{disjunction.NewStore()}
+	input := parse(t, s, "a | b", "!a | b", "!b | c", "!b | !c")
+
+	b := s.Add(input[0].Derive(input[1]))
+	c := s.Add(b.Derive(input[2]))
+	notC := s.Add(b.Derive(input[3]))
+	return s, s.Add(c.Derive(notC))
+}
+
+func TestWriteTree(t *testing.T) {
+	s, empty := lemma(t)
+
+	b := &strings.Builder{}
+	if err := WriteTree(b, s, empty); err != nil {
+		t.Fatalf("FAILED, got an error: %s", err.Error())
+	}
+
+	expected := `(  )T( c )T( !b | c )
+    |     └( b )T( a | b )
+    |           └( !a | b )
+    └( !c )T( !b | !c )
+           └( b )T( a | b )
+                 └( !a | b )
+`
+	if b.String() != expected {
+		t.Errorf("FAILED, expected output\n%s\nnot\n%s", expected, b.String())
+	}
+}
+
+func TestWriteDAG(t *testing.T) {
+	s, empty := lemma(t)
+
+	b := &strings.Builder{}
+	if err := WriteDAG(b, s, empty); err != nil {
+		t.Fatalf("FAILED, got an error: %s", err.Error())
+	}
+
+	expected := `#8 (  )T#6 ( c )T( !b | c )
+       |        └#5 ( b )T( a | b )
+       |                 └( !a | b )
+       └#7 ( !c )T( !b | !c )
+                 └[#5]
+`
+	if b.String() != expected {
+		t.Errorf("FAILED, expected output\n%s\nnot\n%s", expected, b.String())
+	}
+}
+
+func TestWriteDot(t *testing.T) {
+	s, empty := lemma(t)
+
+	b := &strings.Builder{}
+	if err := WriteDot(b, s, []*disjunction.Disjunction{empty}); err != nil {
+		t.Fatalf("FAILED, got an error: %s", err.Error())
+	}
+
+	expected := `digraph proof {
+	c8 [label="(  )", shape=doubleoctagon, style=filled, fillcolor=lightgrey];
+	c6 -> c8;
+	c7 -> c8;
+	c6 [label="( c )", shape=ellipse];
+	c3 -> c6;
+	c5 -> c6;
+	c3 [label="( !b | c )", shape=box];
+	c5 [label="( b )", shape=ellipse];
+	c1 -> c5;
+	c2 -> c5;
+	c1 [label="( a | b )", shape=box];
+	c2 [label="( !a | b )", shape=box];
+	c7 [label="( !c )", shape=ellipse];
+	c4 -> c7;
+	c5 -> c7;
+	c4 [label="( !b | !c )", shape=box];
+}
+`
+	if b.String() != expected {
+		t.Errorf("FAILED, expected output\n%s\nnot\n%s", expected, b.String())
+	}
+}
+
+func TestWriteChain(t *testing.T) {
+	s := store{disjunction.NewStore()}
+	input := parse(t, s, "a | b", "!a | b", "!b")
+
+	b := s.Add(input[0].Derive(input[1]))
+	empty := s.Add(b.Derive(input[2]))
+
+	out := &strings.Builder{}
+	if err := WriteChain(out, s, empty); err != nil {
+		t.Fatalf("FAILED, got an error: %s", err.Error())
+	}
+
+	expected := `( a | b )
+  | ( !a | b )
+( b )
+  | ( !b )
+(  )
+`
+	if out.String() != expected {
+		t.Errorf("FAILED, expected output\n%s\nnot\n%s", expected, out.String())
+	}
+}
+
+// step resolves the center clause of a chain with the side clause
+func step(s store, center *disjunction.Disjunction, side *disjunction.Disjunction) *disjunction.Disjunction {
+	d := center.Derive(side)
+	d.SourceA, d.SourceB = center.ID(), side.ID()
+	return s.Add(d)
+}
+
+func TestIsChain(t *testing.T) {
+	s := store{disjunction.NewStore()}
+	input := parse(t, s, "a | b", "!a | b", "!b | c", "!c", "a | !b", "!a")
+
+	b := step(s, input[0], input[1])
+	c := step(s, b, input[2])
+	if !IsChain(s, step(s, c, input[3])) {
+		t.Errorf("FAILED, expected a chain with input side clauses only")
+	}
+
+	// ( b ) is derived outside of the chain starting at ( a | !b )
+	if IsChain(s, step(s, step(s, input[4], b), input[5])) {
+		t.Errorf("FAILED, expected the derived side clause ( b ) not to be part of the chain")
+	}
+
+	// ( b ) is resolved with again as an ancestor at the end of the chain
+	input = parse(t, s, "a | b", "!a | b", "a | !b", "!a | !b")
+	b = step(s, input[0], input[1])
+	notB := step(s, step(s, b, input[2]), input[3])
+	if !IsChain(s, step(s, notB, b)) {
+		t.Errorf("FAILED, expected the ancestor ( b ) to be part of the chain")
+	}
+}
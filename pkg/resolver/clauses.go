@@ -0,0 +1,57 @@
+package resolver
+
+import "github.com/lukaskurz/rebyre/pkg/disjunction"
+
+// clauseSet is a set of clauses a resolution run works on. It is kept free
+// of subsumed clauses, since they can only derive clauses that are subsumed as well.
+type clauseSet struct {
+	clauses []*disjunction.Disjunction
+	stats   *Stats
+}
+
+// add inserts c into the set and reports wether it was kept. A clause that is
+// subsumed by one of the set is discarded (forward subsumption), otherwise all
+// clauses of the set that c subsumes are retired (backward subsumption).
+func (s *clauseSet) add(c *disjunction.Disjunction) bool {
+	if s.subsumes(c) {
+		s.stats.ForwardSubsumed++
+		return false
+	}
+
+	s.retire(c)
+	s.clauses = append(s.clauses, c)
+
+	return true
+}
+
+// subsumes checks wether a clause of the set subsumes c
+func (s *clauseSet) subsumes(c *disjunction.Disjunction) bool {
+	for _, d := range s.clauses {
+		if d.Subsumes(c) {
+			return true
+		}
+	}
+	return false
+}
+
+// retire removes all clauses of the set that are subsumed by c
+func (s *clauseSet) retire(c *disjunction.Disjunction) {
+	kept := make([]*disjunction.Disjunction, 0, len(s.clauses))
+	for _, d := range s.clauses {
+		if c.Subsumes(d) {
+			s.stats.BackwardSubsumed++
+			continue
+		}
+		kept = append(kept, d)
+	}
+	s.clauses = kept
+}
+
+// ids returns the ids of all clauses of the set
+func (s *clauseSet) ids() map[int]bool {
+	ids := make(map[int]bool, len(s.clauses))
+	for _, c := range s.clauses {
+		ids[c.ID()] = true
+	}
+	return ids
+}
@@ -0,0 +1,131 @@
+package resolver
+
+import (
+	"context"
+
+	"github.com/lukaskurz/rebyre/pkg/disjunction"
+	"github.com/lukaskurz/rebyre/pkg/literal"
+)
+
+// elimination records a variable eliminated by the Davis–Putnam procedure,
+// together with the clauses it occurred positively in
+type elimination struct {
+	variable string
+	positive []*disjunction.Disjunction
+}
+
+// eliminate runs the Davis–Putnam procedure. It picks the variable that yields the
+// fewest resolvents and replaces all clauses containing it by their resolvents on
+// it, until the empty clause is derived or no clause is left.
+//
+// The model assigns the eliminated variables in reverse order. A variable is only
+// set to true if one of the clauses it occurred positively in would be false otherwise.
+func (s *Solver) eliminate(ctx context.Context, result *Result, clauses []*disjunction.Disjunction, variables []string) (Model, error) {
+	set := &clauseSet{clauses: make([]*disjunction.Disjunction, 0, len(clauses)), stats: &result.Stats}
+	for _, c := range clauses {
+		set.add(c)
+	}
+
+	eliminated := make([]elimination, 0)
+	result.Refutations = getEmptyClauses(set.clauses)
+	for len(result.Refutations) == 0 && len(set.clauses) > 0 {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		v := pickVariable(set.clauses)
+		positive, negative, rest := split(set.clauses, v)
+		set.clauses = rest
+
+		derived := make([]*disjunction.Disjunction, 0)
+		for _, p := range positive {
+			for _, n := range negative {
+				// clashing on another variable as well makes the resolvent a tautology
+				if !p.CompatibleWith(n) {
+					result.Stats.Tautologies++
+					continue
+				}
+				if d := p.Derive(n); set.add(d) || (s.Shortest && d.IsEmpty()) {
+					derived = append(derived, result.store.Add(d))
+				}
+			}
+		}
+
+		eliminated = append(eliminated, elimination{variable: v, positive: positive})
+		result.Stats.Rounds++
+		if s.OnEliminate != nil {
+			s.OnEliminate(v, append(positive, negative...), derived)
+		}
+		result.Refutations = getEmptyClauses(derived)
+	}
+
+	if len(result.Refutations) > 0 {
+		result.Verdict = Unsatisfiable
+		return nil, nil
+	}
+
+	model := Model{}
+	for _, v := range variables {
+		model[v] = false
+	}
+	for i := len(eliminated) - 1; i >= 0; i-- {
+		for _, c := range eliminated[i].positive {
+			if !c.Satisfied(model) {
+				model[eliminated[i].variable] = true
+				break
+			}
+		}
+	}
+
+	return model, nil
+}
+
+// pickVariable returns the variable with the fewest pairs of clauses containing it
+// positively and negatively, the first one to appear on ties
+func pickVariable(clauses []*disjunction.Disjunction) string {
+	positive, negative := map[string]int{}, map[string]int{}
+	for _, c := range clauses {
+		seen := map[string]bool{}
+		for _, l := range c.Literals() {
+			if seen[l.String()] {
+				continue
+			}
+			seen[l.String()] = true
+			if l.Negated() {
+				negative[l.Variable()]++
+			} else {
+				positive[l.Variable()]++
+			}
+		}
+	}
+
+	variables := disjunction.Variables(clauses)
+	best := variables[0]
+	for _, v := range variables[1:] {
+		if positive[v]*negative[v] < positive[best]*negative[best] {
+			best = v
+		}
+	}
+	return best
+}
+
+// split divides the clauses into those containing v positively,
+// those containing it negatively and the rest
+func split(clauses []*disjunction.Disjunction, v string) ([]*disjunction.Disjunction, []*disjunction.Disjunction, []*disjunction.Disjunction) {
+	positive := make([]*disjunction.Disjunction, 0)
+	negative := make([]*disjunction.Disjunction, 0)
+	rest := make([]*disjunction.Disjunction, 0, len(clauses))
+
+	for _, c := range clauses {
+		switch {
+		case has(c.Literals(), literal.New(v, false)):
+			positive = append(positive, c)
+		case has(c.Literals(), literal.New(v, true)):
+			negative = append(negative, c)
+		default:
+			rest = append(rest, c)
+		}
+	}
+
+	return positive, negative, rest
+}
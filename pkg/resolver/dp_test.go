@@ -0,0 +1,69 @@
+package resolver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lukaskurz/rebyre/pkg/disjunction"
+)
+
+func TestPickVariable(t *testing.T) {
+	clauses := parse(t, "a | b", "!a | b", "!a | c", "!b | c", "!c | a")
+
+	// a yields 2 * 2 resolvents, b 2 * 1 and c 2 * 1, b appears first
+	if v := pickVariable(clauses); v != "b" {
+		t.Errorf("FAILED, expected b to be picked, not %s", v)
+	}
+
+	clauses = parse(t, "a | b", "!a | b", "a | c", "!a | c", "!b")
+	if v := pickVariable(clauses); v != "c" {
+		t.Errorf("FAILED, expected the pure variable c to be picked, not %s", v)
+	}
+}
+
+func TestSolveDavisPutnam(t *testing.T) {
+	clauses := parse(t, "a | b", "!a | b", "a | !b", "!a | !b")
+
+	var eliminated []string
+	solver := &Solver{
+		Mode: DavisPutnam,
+		OnEliminate: func(v string, removed []*disjunction.Disjunction, derived []*disjunction.Disjunction) {
+			eliminated = append(eliminated, v)
+		},
+	}
+	result, err := solver.Solve(context.Background(), clauses)
+	if err != nil {
+		t.Fatalf("FAILED, got an error: %s", err.Error())
+	}
+
+	if result.Verdict != Unsatisfiable {
+		t.Errorf("FAILED, expected verdict to be %s, not %s", Unsatisfiable, result.Verdict)
+	}
+	if len(eliminated) != 2 || result.Stats.Rounds != 2 {
+		t.Errorf("FAILED, expected both variables to be eliminated, got %v", eliminated)
+	}
+	for _, d := range result.Proof(result.Refutations[0]) {
+		if !derivedCorrectly(result, d) {
+			t.Errorf("FAILED, %s is no resolvent of its sources", d)
+		}
+	}
+}
+
+func TestSolveDavisPutnamModel(t *testing.T) {
+	clauses := parse(t, "a | b | c", "!a | b", "!b | c", "!c | !a", "d | !d")
+
+	result, err := (&Solver{Mode: DavisPutnam}).Solve(context.Background(), clauses)
+	if err != nil {
+		t.Fatalf("FAILED, got an error: %s", err.Error())
+	}
+
+	if result.Verdict != Satisfiable || result.Model == nil {
+		t.Fatalf("FAILED, expected a model, got %s", result.Verdict)
+	}
+	if !result.Model.Satisfies(clauses) {
+		t.Errorf("FAILED, expected %s to satisfy the clauses", result.Model)
+	}
+	if _, ok := result.Model["d"]; !ok {
+		t.Errorf("FAILED, expected the model to assign every variable, got %s", result.Model)
+	}
+}
@@ -0,0 +1,92 @@
+package resolver
+
+import (
+	"context"
+
+	"github.com/lukaskurz/rebyre/pkg/disjunction"
+)
+
+// saturateGiven runs the given-clause algorithm of the Otter prover. The clauses
+// are split into the usable clauses, which were resolved with each other already,
+// and the set of support holding the rest. In every step the selector picks a
+// given clause from the set of support, that is moved to the usable clauses and
+// resolved with all of them. The resolvents join the set of support, until the
+// empty clause is found or the set of support runs empty, which leaves the
+// usable clauses saturated.
+//
+// Usually all clauses start in the set of support. With restrict set, only the
+// goals do and the others start as usable, so they are never resolved with each other.
+func (s *Solver) saturateGiven(ctx context.Context, result *Result, clauses []*disjunction.Disjunction, restrict bool) ([]*disjunction.Disjunction, error) {
+	usable := &clauseSet{clauses: make([]*disjunction.Disjunction, 0, len(clauses)), stats: &result.Stats}
+	sos := &clauseSet{clauses: make([]*disjunction.Disjunction, 0, len(clauses)), stats: &result.Stats}
+	for _, c := range clauses {
+		if restrict && !c.Goal {
+			insert(c, usable, sos)
+		} else {
+			insert(c, sos, usable)
+		}
+	}
+
+	result.Refutations = getEmptyClauses(sos.clauses)
+	for len(result.Refutations) == 0 {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if len(sos.clauses) == 0 {
+			return usable.clauses, nil
+		}
+
+		i := s.Selector.Select(sos.clauses, result.Stats.Rounds)
+		given := sos.clauses[i]
+		sos.clauses = append(sos.clauses[:i:i], sos.clauses[i+1:]...)
+		usable.clauses = append(usable.clauses, given)
+
+		derived := make([]*disjunction.Disjunction, 0)
+		for _, partner := range usable.clauses {
+			if !given.CompatibleWith(partner) || !result.order.permits(given, partner) {
+				continue
+			}
+
+			d := given.Derive(partner)
+			if d.IsTautology() {
+				result.Stats.Tautologies++
+				continue
+			}
+			// the given clause itself may be retired, it is resolved
+			// with the remaining partners nonetheless
+			// with Shortest set, every empty clause of the step is kept
+			// to choose the shortest proof from
+			if !insert(d, sos, usable) && !(s.Shortest && d.IsEmpty()) {
+				continue
+			}
+			derived = append(derived, result.store.Add(d))
+
+			if d.IsEmpty() && !s.Shortest {
+				break
+			}
+		}
+
+		result.Stats.Rounds++
+		if s.OnRound != nil {
+			s.OnRound(derived)
+		}
+		result.Refutations = getEmptyClauses(derived)
+	}
+
+	result.Verdict = Unsatisfiable
+	return nil, nil
+}
+
+// insert adds c to set unless a clause of set or other subsumes it,
+// and retires the clauses of both sets that c subsumes
+func insert(c *disjunction.Disjunction, set *clauseSet, other *clauseSet) bool {
+	if other.subsumes(c) {
+		other.stats.ForwardSubsumed++
+		return false
+	}
+	if !set.add(c) {
+		return false
+	}
+	other.retire(c)
+	return true
+}
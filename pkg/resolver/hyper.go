@@ -0,0 +1,203 @@
+package resolver
+
+import (
+	"context"
+
+	"github.com/lukaskurz/rebyre/pkg/disjunction"
+	"github.com/lukaskurz/rebyre/pkg/literal"
+)
+
+// saturateHyper runs hyperresolution in rounds. For positive hyperresolution the
+// electrons are the positive clauses and every other clause is a nucleus. A nucleus
+// is resolved with one electron for each of its negative literals at once, which
+// yields another positive clause. Negative hyperresolution is the dual with the
+// signs swapped.
+//
+// Only electrons are ever derived, so the nuclei stay the input clauses. In every
+// round only the combinations with at least one electron that is new since the
+// last round are resolved. It returns the saturated electrons.
+func (s *Solver) saturateHyper(ctx context.Context, result *Result, clauses []*disjunction.Disjunction) ([]*disjunction.Disjunction, error) {
+	positive := s.Mode == PositiveHyper
+
+	nuclei := make([]*disjunction.Disjunction, 0)
+	electrons := &clauseSet{clauses: make([]*disjunction.Disjunction, 0), stats: &result.Stats}
+	for _, c := range clauses {
+		if len(clashing(c, positive)) == 0 {
+			electrons.add(c)
+		} else {
+			nuclei = append(nuclei, c)
+		}
+	}
+
+	fresh := electrons.ids()
+	result.Refutations = getEmptyClauses(electrons.clauses)
+	for len(result.Refutations) == 0 {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		derived := make([]*disjunction.Disjunction, 0)
+		for _, nucleus := range nuclei {
+			for _, d := range hyperresolve(nucleus, electrons.clauses, fresh, positive) {
+				if electrons.add(d) || (s.Shortest && d.IsEmpty()) {
+					derived = append(derived, result.store.Add(d))
+				}
+			}
+		}
+		fresh = map[int]bool{}
+		for _, d := range derived {
+			fresh[d.ID()] = true
+		}
+		result.Stats.Rounds++
+		if s.OnRound != nil {
+			s.OnRound(derived)
+		}
+
+		if len(derived) == 0 {
+			return electrons.clauses, nil
+		}
+
+		result.Refutations = getEmptyClauses(derived)
+	}
+
+	result.Verdict = Unsatisfiable
+	return nil, nil
+}
+
+// clashing returns the literals of c an electron has to resolve away, which are
+// the negative ones for positive hyperresolution and the positive ones otherwise
+func clashing(c *disjunction.Disjunction, positive bool) []*literal.Literal {
+	literals := make([]*literal.Literal, 0)
+	for _, l := range c.Literals() {
+		if l.Negated() == positive && !has(literals, l) {
+			literals = append(literals, l)
+		}
+	}
+	return literals
+}
+
+// hyperresolve returns the hyperresolvents of the nucleus with the electrons
+// that use at least one fresh electron. The nucleus is SourceA of each of them,
+// the electrons are SourceB and Extra in the order of the clashing literals.
+//
+// The electrons are indexed by the clashing literal they resolve away, and no
+// electron is used for two of them: the resolvent would contain the electron
+// itself, which subsumes it. Combinations that can't pick a fresh electron
+// anymore are skipped, as are resolvents an earlier combination gave already.
+func hyperresolve(nucleus *disjunction.Disjunction, electrons []*disjunction.Disjunction, fresh map[int]bool, positive bool) []*disjunction.Disjunction {
+	clashes := clashing(nucleus, positive)
+	resolvents := make([]*disjunction.Disjunction, 0)
+
+	candidates := make([][]*disjunction.Disjunction, len(clashes))
+	for i, l := range clashes {
+		candidates[i] = make([]*disjunction.Disjunction, 0)
+		for _, e := range electrons {
+			if containsOpposite(e, l) {
+				candidates[i] = append(candidates[i], e)
+			}
+		}
+		if len(candidates[i]) == 0 {
+			return resolvents
+		}
+	}
+
+	// freshLeft[i] tells if any clashing literal from i on has a fresh electron
+	freshLeft := make([]bool, len(clashes)+1)
+	for i := len(clashes) - 1; i >= 0; i-- {
+		freshLeft[i] = freshLeft[i+1]
+		for _, e := range candidates[i] {
+			freshLeft[i] = freshLeft[i] || fresh[e.ID()]
+		}
+	}
+
+	chosen := make([]*disjunction.Disjunction, len(clashes))
+	var choose func(i int, anyFresh bool)
+	choose = func(i int, anyFresh bool) {
+		if !anyFresh && !freshLeft[i] {
+			return
+		}
+		if i == len(clashes) {
+			resolvent := combine(nucleus, clashes, chosen)
+			for _, other := range resolvents {
+				if other.Equals(resolvent) {
+					return
+				}
+			}
+			resolvents = append(resolvents, resolvent)
+			return
+		}
+		for _, e := range candidates[i] {
+			if used(chosen[:i], e) {
+				continue
+			}
+			chosen[i] = e
+			choose(i+1, anyFresh || fresh[e.ID()])
+		}
+	}
+	choose(0, false)
+
+	return resolvents
+}
+
+func used(chosen []*disjunction.Disjunction, e *disjunction.Disjunction) bool {
+	for _, c := range chosen {
+		if c == e {
+			return true
+		}
+	}
+	return false
+}
+
+// combine resolves the nucleus with one electron for each clashing literal
+func combine(nucleus *disjunction.Disjunction, clashes []*literal.Literal, electrons []*disjunction.Disjunction) *disjunction.Disjunction {
+	literals := make([]*literal.Literal, 0)
+	for _, l := range nucleus.Literals() {
+		if !has(clashes, l) {
+			literals = append(literals, l)
+		}
+	}
+	for i, e := range electrons {
+		for _, l := range e.Literals() {
+			if !l.Opposes(clashes[i]) {
+				literals = append(literals, l)
+			}
+		}
+	}
+
+	resolvent := disjunction.New(literals...)
+	resolvent.Sanitize()
+	resolvent.SourceA = nucleus.ID()
+	resolvent.Goal = nucleus.Goal
+	for i, e := range electrons {
+		if i == 0 {
+			resolvent.SourceB = e.ID()
+		} else {
+			resolvent.Extra = append(resolvent.Extra, e.ID())
+		}
+		resolvent.Goal = resolvent.Goal || e.Goal
+	}
+
+	return resolvent
+}
+
+func has(literals []*literal.Literal, l *literal.Literal) bool {
+	for _, other := range literals {
+		if other.Equals(l) {
+			return true
+		}
+	}
+	return false
+}
+
+// negated returns copies of the clauses with the signs of all literals swapped
+func negated(clauses []*disjunction.Disjunction) []*disjunction.Disjunction {
+	flipped := make([]*disjunction.Disjunction, len(clauses))
+	for i, c := range clauses {
+		literals := c.Literals()
+		for j, l := range literals {
+			literals[j] = literal.New(l.Variable(), !l.Negated())
+		}
+		flipped[i] = disjunction.New(literals...)
+	}
+	return flipped
+}
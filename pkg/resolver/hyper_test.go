@@ -0,0 +1,105 @@
+package resolver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lukaskurz/rebyre/pkg/disjunction"
+)
+
+func TestSolvePositiveHyper(t *testing.T) {
+	clauses := parse(t, "!a | !b | c", "a", "b", "!c")
+
+	result, err := (&Solver{Mode: PositiveHyper}).Solve(context.Background(), clauses)
+	if err != nil {
+		t.Fatalf("FAILED, got an error: %s", err.Error())
+	}
+
+	if result.Verdict != Unsatisfiable {
+		t.Fatalf("FAILED, expected verdict to be %s, not %s", Unsatisfiable, result.Verdict)
+	}
+
+	// "c" is resolved from the nucleus and both electrons at once
+	derived := result.Clauses[len(clauses):]
+	if len(derived) != 2 {
+		t.Fatalf("FAILED, expected 2 clauses to be derived, got %d", len(derived))
+	}
+	c := derived[0]
+	if c.String() != "( c )" || c.SourceA != 1 || c.SourceB != 2 || len(c.Extra) != 1 || c.Extra[0] != 3 {
+		t.Errorf("FAILED, expected ( c ) to be derived from 1, 2 and 3, got %s from %v", c, c.Parents())
+	}
+	if len(result.Proof(result.Refutations[0])) != len(result.Clauses) {
+		t.Errorf("FAILED, expected the proof to use every clause")
+	}
+}
+
+func TestSolveNegativeHyper(t *testing.T) {
+	clauses := parse(t, "!a | !b | c", "a", "b", "!c")
+
+	result, err := (&Solver{Mode: NegativeHyper}).Solve(context.Background(), clauses)
+	if err != nil {
+		t.Fatalf("FAILED, got an error: %s", err.Error())
+	}
+
+	if result.Verdict != Unsatisfiable {
+		t.Fatalf("FAILED, expected verdict to be %s, not %s", Unsatisfiable, result.Verdict)
+	}
+	for _, d := range result.Clauses[len(clauses):] {
+		if len(clashing(d, false)) > 0 {
+			t.Errorf("FAILED, expected only negative clauses to be derived, got %s", d)
+		}
+	}
+}
+
+func TestSolveHyperModel(t *testing.T) {
+	clauses := parse(t, "!a | !b | c", "a | b", "!c | a", "!a | !c | d")
+
+	for _, mode := range []Mode{PositiveHyper, NegativeHyper} {
+		result, err := (&Solver{Mode: mode}).Solve(context.Background(), clauses)
+		if err != nil {
+			t.Fatalf("FAILED, got an error: %s", err.Error())
+		}
+
+		if result.Verdict != Satisfiable || result.Model == nil {
+			t.Fatalf("FAILED, expected a model, got %s", result.Verdict)
+		}
+		if !result.Model.Satisfies(clauses) {
+			t.Errorf("FAILED, expected %s to satisfy the clauses", result.Model)
+		}
+	}
+}
+
+func TestHyperresolve(t *testing.T) {
+	tests := []struct {
+		electrons []string
+		expected  int
+	}{
+		// the same electron can't resolve away both !a and !b
+		{[]string{"a | b"}, 0},
+		{[]string{"a | b", "b"}, 1},
+		// the first and the last combination both give ( c | x | y )
+		{[]string{"a | x", "b | y", "a | y", "b | x"}, 3},
+	}
+
+	for _, test := range tests {
+		store := disjunction.NewStore()
+		nucleus := store.Add(parse(t, "!a | !b | c")[0])
+		electrons := parse(t, test.electrons...)
+		fresh := map[int]bool{}
+		for _, e := range electrons {
+			fresh[store.Add(e).ID()] = true
+		}
+
+		resolvents := hyperresolve(nucleus, electrons, fresh, true)
+		if len(resolvents) != test.expected {
+			t.Errorf("FAILED, expected %d hyperresolvents with %v, got %v", test.expected, test.electrons, resolvents)
+		}
+		for i, r := range resolvents {
+			for _, other := range resolvents[:i] {
+				if r.Equals(other) {
+					t.Errorf("FAILED, expected %s to be derived once only", r)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package resolver
+
+import (
+	"context"
+
+	"github.com/lukaskurz/rebyre/pkg/disjunction"
+)
+
+// searchInput resolves the clauses with input clauses level by level, like
+// saturateLevels but with an input clause as side clause of every step, until
+// the empty clause is derived or nothing new. What input resolution derives
+// from a clause only depends on the clause itself, so clauses subsumed by a
+// known one are discarded.
+//
+// Input resolution is only complete for Horn clauses, otherwise running out of
+// resolvents leaves the verdict unknown.
+func (s *Solver) searchInput(ctx context.Context, result *Result, clauses []*disjunction.Disjunction) error {
+	known := &clauseSet{clauses: make([]*disjunction.Disjunction, 0, len(clauses)), stats: &result.Stats}
+	for _, c := range clauses {
+		known.add(c)
+	}
+
+	fresh := known.ids()
+	result.Refutations = getEmptyClauses(known.clauses)
+	for len(result.Refutations) == 0 {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		derived := make([]*disjunction.Disjunction, 0)
+		for _, center := range known.clauses {
+			if !fresh[center.ID()] {
+				continue
+			}
+			for _, side := range clauses {
+				if !center.CompatibleWith(side) {
+					continue
+				}
+
+				d := center.Derive(side)
+				d.SourceA, d.SourceB = center.ID(), side.ID()
+				if known.add(d) {
+					derived = append(derived, result.store.Add(d))
+				}
+			}
+		}
+		fresh = map[int]bool{}
+		for _, d := range derived {
+			fresh[d.ID()] = true
+		}
+		result.Stats.Rounds++
+		if s.OnRound != nil {
+			s.OnRound(derived)
+		}
+
+		if len(derived) == 0 {
+			return nil
+		}
+
+		result.Refutations = getEmptyClauses(derived)
+	}
+
+	result.Verdict = Unsatisfiable
+	return nil
+}
+
+// horn checks wether every clause has at most one positive literal
+func horn(clauses []*disjunction.Disjunction) bool {
+	for _, c := range clauses {
+		positive := ""
+		for _, l := range c.Literals() {
+			if l.Negated() || l.Variable() == positive {
+				continue
+			}
+			if positive != "" {
+				return false
+			}
+			positive = l.Variable()
+		}
+	}
+	return true
+}
+
+// hornModel returns the least model of satisfiable Horn clauses. A variable is
+// only set to true when a clause would be false otherwise, because all of its
+// negative literals are true.
+func hornModel(clauses []*disjunction.Disjunction, variables []string) Model {
+	model := Model{}
+	for _, v := range variables {
+		model[v] = false
+	}
+
+	for changed := true; changed; {
+		changed = false
+		for _, c := range clauses {
+			if c.Satisfied(model) {
+				continue
+			}
+			for _, l := range c.Literals() {
+				if !l.Negated() {
+					model[l.Variable()] = true
+					changed = true
+				}
+			}
+		}
+	}
+
+	return model
+}
@@ -0,0 +1,87 @@
+package resolver
+
+import (
+	"context"
+
+	"github.com/lukaskurz/rebyre/pkg/disjunction"
+)
+
+// saturateLevels resolves all pairs of clauses in rounds, until a round derives
+// the empty clause or nothing new. It returns the saturated clause set.
+// With sos set, pairs without a goal clause are skipped.
+func (s *Solver) saturateLevels(ctx context.Context, result *Result, clauses []*disjunction.Disjunction, sos bool) ([]*disjunction.Disjunction, error) {
+	active := &clauseSet{clauses: make([]*disjunction.Disjunction, 0, len(clauses)), stats: &result.Stats}
+	for _, c := range clauses {
+		active.add(c)
+	}
+
+	// in every round only the clauses that are new since the last round are
+	// resolved against the whole set, every other pair was already resolved
+	fresh := active.ids()
+	result.Refutations = getEmptyClauses(active.clauses)
+	for len(result.Refutations) == 0 {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		candidates, tautologies := combineDisjunctions(active.clauses, fresh, sos, result.order)
+		combinations := make([]*disjunction.Disjunction, 0)
+		for _, c := range candidates {
+			// the first empty clause subsumes the others, which are
+			// only kept to choose the shortest proof from
+			if active.add(c) || (s.Shortest && c.IsEmpty()) {
+				combinations = append(combinations, result.store.Add(c))
+			}
+		}
+		fresh = map[int]bool{}
+		for _, c := range combinations {
+			fresh[c.ID()] = true
+		}
+		result.Stats.Rounds++
+		result.Stats.Tautologies += tautologies
+		if s.OnRound != nil {
+			s.OnRound(combinations)
+		}
+
+		// no new clauses in this round means the set is saturated,
+		// so the empty clause can never be derived
+		if len(combinations) == 0 {
+			return active.clauses, nil
+		}
+
+		result.Refutations = getEmptyClauses(combinations)
+	}
+
+	result.Verdict = Unsatisfiable
+	return nil, nil
+}
+
+// combineDisjunctions resolves every fresh clause against every clause of the
+// set and returns the resolvents, together with the number of tautological
+// resolvents that were discarded. Only pairs the ordering permits are resolved,
+// and with sos set only pairs with a goal.
+func combineDisjunctions(disjunctions []*disjunction.Disjunction, fresh map[int]bool, sos bool, order ordering) ([]*disjunction.Disjunction, int) {
+	combinations := make([]*disjunction.Disjunction, 0)
+	tautologies := 0
+
+	for _, base := range disjunctions {
+		if !fresh[base.ID()] {
+			continue
+		}
+		for _, target := range disjunctions {
+			if sos && !base.Goal && !target.Goal {
+				continue
+			}
+			if base.CompatibleWith(target) && order.permits(base, target) {
+				derived := base.Derive(target)
+				if derived.IsTautology() {
+					tautologies++
+					continue
+				}
+				combinations = append(combinations, derived)
+			}
+		}
+	}
+
+	return combinations, tautologies
+}
@@ -0,0 +1,202 @@
+package resolver
+
+import (
+	"context"
+
+	"github.com/lukaskurz/rebyre/pkg/disjunction"
+	"github.com/lukaskurz/rebyre/pkg/literal"
+)
+
+// chain is a linear derivation, each center clause was derived from the
+// previous one and the side clause at the same index
+type chain struct {
+	centers []*disjunction.Disjunction
+	sides   []*disjunction.Disjunction
+}
+
+// searchLinear looks for a linear refutation starting at one of the clauses,
+// goals first. It works like model elimination: the literals of the center
+// are kept on a stack and always the latest one is resolved away, either with
+// the ancestor center that resolved away its complement, or with an input
+// clause. An input clause must not bring back a literal that was resolved away
+// and is still on the stack, which bounds their number by the number of variables.
+//
+// The bound on the literals resolved away is raised one by one. If a bound is
+// searched completely without hitting it anywhere, there is no refutation.
+// Only the clauses of a refutation are added to the store, the rest of the
+// search space is thrown away.
+func (s *Solver) searchLinear(ctx context.Context, result *Result, clauses []*disjunction.Disjunction) error {
+	tops := make([]*disjunction.Disjunction, 0, len(clauses))
+	for _, c := range clauses {
+		if c.Goal {
+			tops = append(tops, c)
+		}
+	}
+	for _, c := range clauses {
+		if !c.Goal {
+			tops = append(tops, c)
+		}
+	}
+
+	search := &linearSearch{ctx: ctx, clauses: clauses}
+	for depth := 0; ; depth++ {
+		result.Stats.Rounds = depth
+		search.depth = depth
+		search.cut = false
+
+		for _, top := range tops {
+			search.chain = chain{centers: []*disjunction.Disjunction{top}}
+			stack := make([]item, 0, top.Length())
+			for _, l := range top.Literals() {
+				stack, _ = push(stack, l)
+			}
+
+			if search.find(stack) {
+				stored := search.chain.store(result)
+				if s.OnRound != nil {
+					s.OnRound(stored[1:])
+				}
+				result.Refutations = stored[len(stored)-1:]
+				result.Verdict = Unsatisfiable
+				return nil
+			}
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+
+		if !search.cut {
+			result.Verdict = Satisfiable
+			return nil
+		}
+	}
+}
+
+// item is a literal on the stack of the linear search
+type item struct {
+	literal *literal.Literal
+	// center is the index of the center the literal was resolved away from,
+	// or -1 while it is part of the current center
+	center int
+}
+
+type linearSearch struct {
+	ctx     context.Context
+	clauses []*disjunction.Disjunction
+	chain   chain
+	// depth bounds the number of literals on the stack that were resolved away
+	depth int
+	// cut reports that the depth stopped the search somewhere
+	cut bool
+}
+
+// find extends the chain until it ends with the empty clause,
+// the stack holds the literals of its last center
+func (l *linearSearch) find(stack []item) bool {
+	// literals resolved away on top of the stack have nothing left above them
+	for len(stack) > 0 && stack[len(stack)-1].center >= 0 {
+		stack = stack[:len(stack)-1]
+	}
+	if len(stack) == 0 {
+		return true
+	}
+	if l.ctx.Err() != nil {
+		return false
+	}
+
+	center := l.chain.centers[len(l.chain.centers)-1]
+	selected := stack[len(stack)-1].literal
+
+	// the other literals of an ancestor below the selected one are still part
+	// of the center, so resolving with it just drops the selected literal
+	resolved := 0
+	for _, it := range stack {
+		if it.center < 0 {
+			continue
+		}
+		resolved++
+		if ancestor := l.chain.centers[it.center]; it.literal.Opposes(selected) && center.CompatibleWith(ancestor) {
+			return l.step(center.Derive(ancestor), ancestor, stack[:len(stack)-1])
+		}
+	}
+
+	if resolved == l.depth {
+		l.cut = true
+		return false
+	}
+
+	for _, side := range l.clauses {
+		if !center.CompatibleWith(side) || !containsOpposite(side, selected) {
+			continue
+		}
+
+		next := append(stack[:0:0], stack...)
+		next[len(next)-1].center = len(l.chain.centers) - 1
+		regular := true
+		for _, m := range side.Literals() {
+			if !m.Opposes(selected) && regular {
+				next, regular = push(next, m)
+			}
+		}
+
+		if regular && l.step(center.Derive(side), side, next) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// step appends the resolvent d of the center with side to the chain and
+// continues the search from there, it is removed again if that fails
+func (l *linearSearch) step(d *disjunction.Disjunction, side *disjunction.Disjunction, stack []item) bool {
+	l.chain.centers = append(l.chain.centers, d)
+	l.chain.sides = append(l.chain.sides, side)
+	if l.find(stack) {
+		return true
+	}
+	l.chain.centers = l.chain.centers[:len(l.chain.centers)-1]
+	l.chain.sides = l.chain.sides[:len(l.chain.sides)-1]
+	return false
+}
+
+// push adds l to the stack unless it is on it already. It reports false
+// if l was resolved away before, as the search would run in circles.
+func push(stack []item, l *literal.Literal) ([]item, bool) {
+	for _, it := range stack {
+		if it.literal.Equals(l) {
+			return stack, it.center < 0
+		}
+	}
+	return append(stack, item{literal: l, center: -1}), true
+}
+
+func containsOpposite(d *disjunction.Disjunction, l *literal.Literal) bool {
+	for _, dl := range d.Literals() {
+		if dl.Opposes(l) {
+			return true
+		}
+	}
+	return false
+}
+
+// store adds the derived centers to the store of the result, with the previous
+// center as SourceA and the side clause as SourceB, and returns all centers
+func (c *chain) store(result *Result) []*disjunction.Disjunction {
+	stored := []*disjunction.Disjunction{c.centers[0]}
+	for i, center := range c.centers[1:] {
+		side := c.sides[i]
+		for j := range c.centers[:i+1] {
+			if c.centers[j] == side {
+				side = stored[j]
+			}
+		}
+
+		d := disjunction.New(center.Literals()...)
+		d.SourceA = stored[i].ID()
+		d.SourceB = side.ID()
+		d.Goal = center.Goal
+		stored = append(stored, result.store.Add(d))
+	}
+	return stored
+}
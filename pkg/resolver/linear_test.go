@@ -0,0 +1,92 @@
+package resolver
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestSolveLinear(t *testing.T) {
+	clauses := parse(t, "a | b", "!a | b", "a | !b", "!a | !b")
+
+	result, err := (&Solver{Mode: Linear}).Solve(context.Background(), clauses)
+	if err != nil {
+		t.Fatalf("FAILED, got an error: %s", err.Error())
+	}
+
+	if result.Verdict != Unsatisfiable || len(result.Refutations) != 1 {
+		t.Fatalf("FAILED, expected one refutation, got %s", result.Verdict)
+	}
+
+	// every resolvent continues the chain of the previous one
+	derived := result.Clauses[len(clauses):]
+	for i, d := range derived {
+		previous := d.SourceA
+		if i > 0 && previous != derived[i-1].ID() {
+			t.Errorf("FAILED, expected %s to be derived from %s, not from %d", d, derived[i-1], previous)
+		}
+		if !derivedCorrectly(result, d) {
+			t.Errorf("FAILED, %s is no resolvent of its sources", d)
+		}
+	}
+	if result.Refutations[0] != derived[len(derived)-1] {
+		t.Errorf("FAILED, expected the chain to end with the empty clause")
+	}
+}
+
+func TestSolveInput(t *testing.T) {
+	horn := parse(t, "!a | !b | c", "a", "!a | b", "!c")
+	result, err := (&Solver{Mode: Input}).Solve(context.Background(), horn)
+	if err != nil {
+		t.Fatalf("FAILED, got an error: %s", err.Error())
+	}
+	if result.Verdict != Unsatisfiable {
+		t.Errorf("FAILED, expected verdict to be %s, not %s", Unsatisfiable, result.Verdict)
+	}
+	for _, d := range result.Clauses[len(horn):] {
+		if d.SourceB > len(horn) {
+			t.Errorf("FAILED, expected %s to be resolved with an input clause, not %d", d, d.SourceB)
+		}
+	}
+
+	// the refutation needs to resolve with an ancestor, which input resolution can't
+	clauses := parse(t, "a | b", "!a | b", "a | !b", "!a | !b")
+	result, err = (&Solver{Mode: Input}).Solve(context.Background(), clauses)
+	if err != nil {
+		t.Fatalf("FAILED, got an error: %s", err.Error())
+	}
+	if result.Verdict != Unknown {
+		t.Errorf("FAILED, expected verdict to be %s, not %s", Unknown, result.Verdict)
+	}
+	// for Horn clauses running out of resolvents means there is no refutation
+	horn = parse(t, "!a | !b | c", "a", "!a | b", "!c | d", "!e | a")
+	result, err = (&Solver{Mode: Input}).Solve(context.Background(), horn)
+	if err != nil {
+		t.Fatalf("FAILED, got an error: %s", err.Error())
+	}
+	if result.Verdict != Satisfiable {
+		t.Errorf("FAILED, expected verdict to be %s, not %s", Satisfiable, result.Verdict)
+	}
+	expected := Model{"a": true, "b": true, "c": true, "d": true, "e": false}
+	if !reflect.DeepEqual(result.Model, expected) {
+		t.Errorf("FAILED, expected the least model %s, not %v", expected, result.Model)
+	}
+}
+
+func TestSolveLinearSatisfiable(t *testing.T) {
+	clauses := parse(t, "a | b", "!a | b", "!b | c")
+
+	result, err := (&Solver{Mode: Linear}).Solve(context.Background(), clauses)
+	if err != nil {
+		t.Fatalf("FAILED, got an error: %s", err.Error())
+	}
+	if result.Verdict != Satisfiable {
+		t.Errorf("FAILED, expected verdict to be %s, not %s", Satisfiable, result.Verdict)
+	}
+	if len(result.Clauses) != len(clauses) {
+		t.Errorf("FAILED, expected no clause to be kept from the search, got %d", len(result.Clauses))
+	}
+	if result.Model == nil || !result.Model.Satisfies(clauses) {
+		t.Errorf("FAILED, expected a model of the input, got %v", result.Model)
+	}
+}
@@ -0,0 +1,92 @@
+package resolver
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/lukaskurz/rebyre/pkg/disjunction"
+)
+
+// Model is a truth assignment to the variables of a clause set
+type Model map[string]bool
+
+// Satisfies checks wether every clause is satisfied by the model
+func (m Model) Satisfies(clauses []*disjunction.Disjunction) bool {
+	for _, c := range clauses {
+		if !c.Satisfied(m) {
+			return false
+		}
+	}
+	return true
+}
+
+// String prints the model as the set of true literals, sorted by variable.
+//
+// Example: "{ a, !b, c }"
+func (m Model) String() string {
+	variables := make([]string, 0, len(m))
+	for v := range m {
+		variables = append(variables, v)
+	}
+	sort.Strings(variables)
+
+	literals := make([]string, len(variables))
+	for i, v := range variables {
+		if m[v] {
+			literals[i] = v
+		} else {
+			literals[i] = "!" + v
+		}
+	}
+
+	return fmt.Sprintf("{ %s }", strings.Join(literals, ", "))
+}
+
+// with returns the model with the values of the variables in other replaced
+func (m Model) with(other Model) Model {
+	for v, value := range other {
+		m[v] = value
+	}
+	return m
+}
+
+// buildModel constructs a model of a clause set that is saturated under resolution
+// and does not contain the empty clause.
+//
+// The variables are assigned one after another in the given order. A variable is
+// set to true only if some clause, whose last variable in that order it is, would
+// otherwise be false. Saturation guarantees that this never falsifies a clause.
+func buildModel(clauses []*disjunction.Disjunction, variables []string) Model {
+	position := map[string]int{}
+	for i, v := range variables {
+		position[v] = i
+	}
+
+	// group the clauses by their last variable
+	byLast := make([][]*disjunction.Disjunction, len(variables))
+	for _, c := range clauses {
+		last := -1
+		for _, l := range c.Literals() {
+			if position[l.Variable()] > last {
+				last = position[l.Variable()]
+			}
+		}
+		if last >= 0 {
+			byLast[last] = append(byLast[last], c)
+		}
+	}
+
+	model := Model{}
+	for i, v := range variables {
+		model[v] = false
+		for _, c := range byLast[i] {
+			if !c.Satisfied(model) {
+				model[v] = true
+				break
+			}
+		}
+	}
+
+	return model
+}
@@ -0,0 +1,61 @@
+package resolver
+
+import (
+	"context"
+	"testing"
+)
+
+func TestModelSatisfies(t *testing.T) {
+	clauses := parse(t, "a | b", "!a | c")
+
+	models := []Model{
+		{"a": true, "b": false, "c": true},
+		{"a": false, "b": true, "c": false},
+		{"a": true, "b": true, "c": false},
+		{},
+	}
+
+	results := []bool{
+		true,
+		true,
+		false,
+		false,
+	}
+
+	for i, m := range models {
+		if m.Satisfies(clauses) != results[i] {
+			t.Errorf("FAILED, expected models[%d] to satisfy the clauses %t", i, results[i])
+		}
+	}
+}
+
+func TestModelString(t *testing.T) {
+	m := Model{"c": true, "a": false, "b": true}
+	if m.String() != "{ !a, b, c }" {
+		t.Errorf("FAILED, expected model string to be \"{ !a, b, c }\", not \"%s\"", m.String())
+	}
+}
+
+func TestSolveModel(t *testing.T) {
+	inputs := [][]string{
+		{"a | b", "!a | b"},
+		{"a | b | c", "!a | !b", "!b | !c", "!a | !c", "a | !c"},
+		{"!a", "a | b", "!b | c", "!c | d | !a"},
+	}
+
+	for i, input := range inputs {
+		clauses := parse(t, input...)
+		result, err := (&Solver{}).Solve(context.Background(), clauses)
+		if err != nil {
+			t.Fatalf("FAILED, got an error: %s", err.Error())
+		}
+
+		if result.Verdict != Satisfiable {
+			t.Errorf("FAILED, expected inputs[%d] to be %s, not %s", i, Satisfiable, result.Verdict)
+			continue
+		}
+		if result.Model == nil || !result.Model.Satisfies(clauses) {
+			t.Errorf("FAILED, expected a model for inputs[%d], got %v", i, result.Model)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package resolver
+
+import "github.com/lukaskurz/rebyre/pkg/disjunction"
+
+// ordering ranks the variables for ordered resolution, the greatest one has rank 0
+type ordering map[string]int
+
+// newOrdering ranks the variables by their position in order, the variables
+// missing from it are smaller, in the order they are given. Variables of order
+// that are not among the given ones are left out.
+func newOrdering(order []string, variables []string) ordering {
+	known := map[string]bool{}
+	for _, v := range variables {
+		known[v] = true
+	}
+
+	o := ordering{}
+	for _, v := range append(order[:len(order):len(order)], variables...) {
+		if _, ok := o[v]; !ok && known[v] {
+			o[v] = len(o)
+		}
+	}
+	return o
+}
+
+// permits checks wether the two clauses clash on a variable that is the greatest
+// of both of them. Without an ordering any two clauses may be resolved.
+func (o ordering) permits(a *disjunction.Disjunction, b *disjunction.Disjunction) bool {
+	if o == nil {
+		return true
+	}
+
+	for _, la := range a.Literals() {
+		for _, lb := range b.Literals() {
+			if la.Opposes(lb) {
+				return o.maximal(a, la.Variable()) && o.maximal(b, la.Variable())
+			}
+		}
+	}
+	return false
+}
+
+// maximal checks wether no variable of the clause is greater than v
+func (o ordering) maximal(d *disjunction.Disjunction, v string) bool {
+	for _, l := range d.Literals() {
+		if o[l.Variable()] < o[v] {
+			return false
+		}
+	}
+	return true
+}
+
+// ascending returns the variables from the smallest to the greatest
+func (o ordering) ascending() []string {
+	variables := make([]string, len(o))
+	for v, rank := range o {
+		variables[len(o)-1-rank] = v
+	}
+	return variables
+}
@@ -0,0 +1,81 @@
+package resolver
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestNewOrdering(t *testing.T) {
+	o := newOrdering([]string{"c", "x", "a"}, []string{"a", "b", "c", "d"})
+
+	expected := ordering{"c": 0, "a": 1, "b": 2, "d": 3}
+	if !reflect.DeepEqual(o, expected) {
+		t.Errorf("FAILED, expected %v, got %v", expected, o)
+	}
+	if ascending := o.ascending(); !reflect.DeepEqual(ascending, []string{"d", "b", "a", "c"}) {
+		t.Errorf("FAILED, expected the variables from d to c, got %v", ascending)
+	}
+}
+
+func TestOrderingPermits(t *testing.T) {
+	o := newOrdering([]string{"a", "b", "c"}, []string{"a", "b", "c"})
+
+	tests := []struct {
+		a, b     string
+		expected bool
+	}{
+		{"a | b", "!a | c", true},
+		{"a | b", "!b | c", false},
+		{"b | c", "!b", true},
+		{"b | c", "!c", false},
+		{"a", "b", false},
+	}
+
+	for _, test := range tests {
+		clauses := parse(t, test.a, test.b)
+		if o.permits(clauses[0], clauses[1]) != test.expected {
+			t.Errorf("FAILED, expected resolving %s and %s to be permitted: %t", test.a, test.b, test.expected)
+		}
+	}
+
+	if !ordering(nil).permits(parse(t, "a | b")[0], parse(t, "!b")[0]) {
+		t.Errorf("FAILED, expected no ordering to permit every pair")
+	}
+}
+
+func TestSolveOrdered(t *testing.T) {
+	clauses := parse(t, "a | b", "!a | b", "a | !b", "!a | !b", "c | !b")
+
+	for _, order := range [][]string{nil, {"b", "a"}} {
+		result, err := (&Solver{Mode: Ordered, Order: order}).Solve(context.Background(), clauses)
+		if err != nil {
+			t.Fatalf("FAILED, got an error: %s", err.Error())
+		}
+
+		if result.Verdict != Unsatisfiable {
+			t.Errorf("FAILED, expected verdict to be %s, not %s", Unsatisfiable, result.Verdict)
+		}
+		for _, d := range result.Clauses[len(clauses):] {
+			if !result.order.permits(result.Clause(d.SourceA), result.Clause(d.SourceB)) {
+				t.Errorf("FAILED, %s was not derived on the greatest variable of %v", d, order)
+			}
+		}
+	}
+}
+
+func TestSolveOrderedModel(t *testing.T) {
+	clauses := parse(t, "a | b | c", "!a | b", "!b | c", "!c | !a")
+
+	result, err := (&Solver{Mode: Ordered, Order: []string{"c", "b", "a"}}).Solve(context.Background(), clauses)
+	if err != nil {
+		t.Fatalf("FAILED, got an error: %s", err.Error())
+	}
+
+	if result.Verdict != Satisfiable || result.Model == nil {
+		t.Fatalf("FAILED, expected a model, got %s", result.Verdict)
+	}
+	if !result.Model.Satisfies(clauses) {
+		t.Errorf("FAILED, expected %s to satisfy the clauses", result.Model)
+	}
+}
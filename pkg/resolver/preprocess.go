@@ -0,0 +1,116 @@
+package resolver
+
+import (
+	"github.com/lukaskurz/rebyre/pkg/disjunction"
+	"github.com/lukaskurz/rebyre/pkg/literal"
+)
+
+// preprocess simplifies the clauses before the saturation starts.
+//
+// Unit propagation resolves every unit clause with all clauses containing its
+// complement, each of these unit resolutions is a step of the proof. Clauses
+// containing the unit itself are satisfied by it and removed, like the unit.
+// Pure literal elimination then removes all clauses containing a literal whose
+// complement appears nowhere, as making it true satisfies them.
+//
+// It returns the remaining clauses and the assignment fixed by the units and pure
+// literals. If propagation derives the empty clause, the result is unsatisfiable.
+func (s *Solver) preprocess(result *Result, clauses []*disjunction.Disjunction) ([]*disjunction.Disjunction, Model) {
+	fixed := Model{}
+	derived := make([]*disjunction.Disjunction, 0)
+	pure := make([]*literal.Literal, 0)
+	defer func() {
+		if s.OnPreprocess != nil {
+			s.OnPreprocess(derived, pure)
+		}
+	}()
+
+	for {
+		var unit *disjunction.Disjunction
+		for _, c := range clauses {
+			if c.Length() == 1 {
+				unit = c
+				break
+			}
+		}
+		if unit == nil {
+			break
+		}
+
+		l := unit.Literals()[0]
+		fixed[l.Variable()] = !l.Negated()
+
+		next := make([]*disjunction.Disjunction, 0, len(clauses))
+		for _, c := range clauses {
+			if c == unit || contains(c, l) {
+				continue
+			}
+			if !contains(c, literal.New(l.Variable(), !l.Negated())) {
+				next = append(next, c)
+				continue
+			}
+
+			d := result.store.Add(c.Derive(unit))
+			derived = append(derived, d)
+			result.Stats.UnitResolutions++
+			if d.IsEmpty() {
+				result.Refutations = []*disjunction.Disjunction{d}
+				result.Verdict = Unsatisfiable
+				result.Propagated = true
+				return nil, fixed
+			}
+			next = append(next, d)
+		}
+		clauses = next
+	}
+
+	for {
+		l := findPure(clauses)
+		if l == nil {
+			return clauses, fixed
+		}
+
+		pure = append(pure, l)
+		fixed[l.Variable()] = !l.Negated()
+		result.Stats.PureLiterals++
+
+		next := make([]*disjunction.Disjunction, 0, len(clauses))
+		for _, c := range clauses {
+			if !contains(c, l) {
+				next = append(next, c)
+			}
+		}
+		clauses = next
+	}
+}
+
+func contains(c *disjunction.Disjunction, l *literal.Literal) bool {
+	for _, cl := range c.Literals() {
+		if cl.Equals(l) {
+			return true
+		}
+	}
+	return false
+}
+
+// findPure returns a literal whose complement appears in none of the clauses, or nil
+func findPure(clauses []*disjunction.Disjunction) *literal.Literal {
+	negated := map[string]map[bool]bool{}
+	literals := make([]*literal.Literal, 0)
+	for _, c := range clauses {
+		for _, l := range c.Literals() {
+			if negated[l.Variable()] == nil {
+				negated[l.Variable()] = map[bool]bool{}
+				literals = append(literals, l)
+			}
+			negated[l.Variable()][l.Negated()] = true
+		}
+	}
+
+	for _, l := range literals {
+		if len(negated[l.Variable()]) == 1 {
+			return l
+		}
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package resolver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lukaskurz/rebyre/pkg/disjunction"
+	"github.com/lukaskurz/rebyre/pkg/literal"
+)
+
+func TestPreprocessPropagation(t *testing.T) {
+	clauses := parse(t, "a", "!a | b", "!b | c", "!c")
+
+	var derived []*disjunction.Disjunction
+	solver := &Solver{
+		Preprocess: true,
+		OnPreprocess: func(d []*disjunction.Disjunction, pure []*literal.Literal) {
+			derived = d
+		},
+	}
+	result, err := solver.Solve(context.Background(), clauses)
+	if err != nil {
+		t.Fatalf("FAILED, got an error: %s", err.Error())
+	}
+
+	if result.Verdict != Unsatisfiable || !result.Propagated {
+		t.Errorf("FAILED, expected propagation to refute the clauses, got %s", result.Verdict)
+	}
+	if result.Stats.Rounds != 0 {
+		t.Errorf("FAILED, expected no saturation round, got %d", result.Stats.Rounds)
+	}
+	if len(derived) != 3 || result.Stats.UnitResolutions != 3 {
+		t.Errorf("FAILED, expected 3 unit resolutions, got %d", result.Stats.UnitResolutions)
+	}
+
+	proof := result.Proof(result.Refutations[0])
+	for _, d := range proof {
+		if !derivedCorrectly(result, d) {
+			t.Errorf("FAILED, %s is no resolvent of its sources", d)
+		}
+	}
+	if len(proof) != 7 {
+		t.Errorf("FAILED, expected the proof to use all 4 inputs and 3 resolvents, got %d clauses", len(proof))
+	}
+}
+
+func TestPreprocessPureLiterals(t *testing.T) {
+	clauses := parse(t, "a | b", "a | !b", "!c | d", "!c | !d", "e | f", "!e | f")
+
+	var pure []*literal.Literal
+	solver := &Solver{
+		Preprocess: true,
+		OnPreprocess: func(d []*disjunction.Disjunction, p []*literal.Literal) {
+			pure = p
+		},
+	}
+	result, err := solver.Solve(context.Background(), clauses)
+	if err != nil {
+		t.Fatalf("FAILED, got an error: %s", err.Error())
+	}
+
+	if result.Verdict != Satisfiable || result.Propagated {
+		t.Errorf("FAILED, expected verdict to be %s, not %s", Satisfiable, result.Verdict)
+	}
+	expected := []string{"a", "!c", "f"}
+	if len(pure) != len(expected) || result.Stats.PureLiterals != len(expected) {
+		t.Fatalf("FAILED, expected the pure literals %v, got %v", expected, pure)
+	}
+	for i, l := range pure {
+		if l.String() != expected[i] {
+			t.Errorf("FAILED, expected pure literal %s, not %s", expected[i], l)
+		}
+	}
+	if len(result.Clauses) != len(clauses) {
+		t.Errorf("FAILED, expected nothing to be derived, got %d clauses", len(result.Clauses))
+	}
+	if !result.Model.Satisfies(clauses) {
+		t.Errorf("FAILED, expected %s to satisfy the clauses", result.Model)
+	}
+}
+
+func TestPreprocessSatisfiable(t *testing.T) {
+	clauses := parse(t, "b", "!b | a | c", "!a | !c", "a | !c", "z", "!z | !a | d")
+
+	result, err := (&Solver{Preprocess: true}).Solve(context.Background(), clauses)
+	if err != nil {
+		t.Fatalf("FAILED, got an error: %s", err.Error())
+	}
+
+	if result.Verdict != Satisfiable || result.Model == nil {
+		t.Errorf("FAILED, expected a model, got %s", result.Verdict)
+	}
+	if !result.Model.Satisfies(clauses) {
+		t.Errorf("FAILED, expected %s to satisfy the clauses", result.Model)
+	}
+}
@@ -0,0 +1,222 @@
+package resolver
+
+import (
+	"context"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+
+	"github.com/lukaskurz/rebyre/pkg/disjunction"
+	"github.com/lukaskurz/rebyre/pkg/internal/clausetest"
+	"github.com/lukaskurz/rebyre/pkg/literal"
+)
+
+// cnf is a random clause set over a few variables, generated by testing/quick
+type cnf []*disjunction.Disjunction
+
+// Generate creates up to 10 clauses of up to 4 literals over the variables a to e,
+// a few of them empty and about every fourth one a goal
+func (cnf) Generate(r *rand.Rand, size int) reflect.Value {
+	clauses := clausetest.Generator{Clauses: 10, Width: 4, Variables: 5, Empty: true}.Generate(r)
+	for _, c := range clauses {
+		c.Goal = r.Intn(4) == 0
+	}
+
+	return reflect.ValueOf(cnf(clauses))
+}
+
+func (c cnf) String() string {
+	text := ""
+	for i, d := range c {
+		if i > 0 {
+			text += " & "
+		}
+		text += d.String()
+	}
+	return text
+}
+
+func checkVerdict(t *testing.T, solver *Solver) func(c cnf) bool {
+	return func(c cnf) bool {
+		result, err := solver.Solve(context.Background(), c)
+		if err != nil {
+			t.Errorf("FAILED, got an error for %s: %s", c, err.Error())
+			return false
+		}
+
+		expected := Unsatisfiable
+		if clausetest.Satisfiable(c) {
+			expected = Satisfiable
+			if solver.Mode == Input && !horn(c) {
+				expected = Unknown
+			}
+		}
+		if result.Verdict != expected {
+			t.Errorf("FAILED, expected %s to be %s, not %s", c, expected, result.Verdict)
+			return false
+		}
+
+		// the linear mode only finds a model if the input happens to yield one
+		if result.Verdict == Satisfiable && (result.Model == nil && solver.Mode != Linear || result.Model != nil && !result.Model.Satisfies(c)) {
+			t.Errorf("FAILED, expected a model for %s, got %v", c, result.Model)
+			return false
+		}
+
+		for _, r := range result.Refutations {
+			for _, d := range result.Proof(r) {
+				if !derivedCorrectly(result, d) {
+					t.Errorf("FAILED, %s is no resolvent of its sources in the proof of %s", d, c)
+					return false
+				}
+			}
+		}
+
+		return true
+	}
+}
+
+// derivedCorrectly checks that a derived clause is the resolvent of its two
+// sources on a literal of one source whose complement is in the other source.
+// A clause with more sources has to follow from them.
+func derivedCorrectly(result *Result, d *disjunction.Disjunction) bool {
+	if d.SourceA == 0 && d.SourceB == 0 {
+		return true
+	}
+	if len(d.Extra) > 0 {
+		sources := make([]*disjunction.Disjunction, 0)
+		for _, id := range d.Parents() {
+			sources = append(sources, result.Clause(id))
+		}
+		return clausetest.Entails(sources, d)
+	}
+	a, b := result.Clause(d.SourceA), result.Clause(d.SourceB)
+
+	for _, la := range a.Literals() {
+		for _, lb := range b.Literals() {
+			if !la.Opposes(lb) {
+				continue
+			}
+
+			expected := make([]*literal.Literal, 0)
+			for _, l := range a.Literals() {
+				if !l.Equals(la) {
+					expected = append(expected, l)
+				}
+			}
+			for _, l := range b.Literals() {
+				if !l.Equals(lb) {
+					expected = append(expected, l)
+				}
+			}
+
+			resolvent := disjunction.New(expected...)
+			resolvent.Sanitize()
+			if resolvent.Equals(d) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func TestSolveProperties(t *testing.T) {
+	config := &quick.Config{MaxCount: 300, Rand: rand.New(rand.NewSource(1))}
+	if err := quick.Check(checkVerdict(t, &Solver{}), config); err != nil {
+		t.Error(err)
+	}
+
+	t.Run("without goals", func(t *testing.T) {
+		check := checkVerdict(t, &Solver{})
+		withoutGoals := func(c cnf) bool {
+			for _, d := range c {
+				d.Goal = false
+			}
+			return check(c)
+		}
+		if err := quick.Check(withoutGoals, config); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("preprocessed", func(t *testing.T) {
+		if err := quick.Check(checkVerdict(t, &Solver{Preprocess: true}), config); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("shortest refutation", func(t *testing.T) {
+		if err := quick.Check(checkVerdict(t, &Solver{Shortest: true}), config); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("linear", func(t *testing.T) {
+		if err := quick.Check(checkVerdict(t, &Solver{Mode: Linear}), config); err != nil {
+			t.Error(err)
+		}
+	})
+
+	orders := map[string][]string{
+		"ordered":          nil,
+		"ordered reversed": {"e", "d", "c", "b", "a"},
+	}
+	for name, order := range orders {
+		t.Run(name, func(t *testing.T) {
+			if err := quick.Check(checkVerdict(t, &Solver{Mode: Ordered, Order: order}), config); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+
+	for name, mode := range map[string]Mode{"positive hyper": PositiveHyper, "negative hyper": NegativeHyper} {
+		t.Run(name, func(t *testing.T) {
+			if err := quick.Check(checkVerdict(t, &Solver{Mode: mode}), config); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+
+	t.Run("davis putnam", func(t *testing.T) {
+		if err := quick.Check(checkVerdict(t, &Solver{Mode: DavisPutnam}), config); err != nil {
+			t.Error(err)
+		}
+	})
+
+	// input resolution is only complete for Horn clauses
+	t.Run("input", func(t *testing.T) {
+		check := checkVerdict(t, &Solver{Mode: Input})
+		horn := func(c cnf) bool {
+			clauses := make(cnf, 0, len(c))
+			for _, d := range c {
+				positive := 0
+				for _, l := range d.Literals() {
+					if !l.Negated() {
+						positive++
+					}
+				}
+				if positive <= 1 {
+					clauses = append(clauses, d)
+				}
+			}
+			return check(clauses)
+		}
+		if err := quick.Check(horn, config); err != nil {
+			t.Error(err)
+		}
+	})
+
+	selectors := map[string]Selector{
+		"shortest": ShortestFirst{},
+		"fifo":     FIFO{},
+		"weighted": AgeWeight{PickRatio: 2},
+	}
+	for name, selector := range selectors {
+		t.Run(name, func(t *testing.T) {
+			if err := quick.Check(checkVerdict(t, &Solver{Selector: selector}), config); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
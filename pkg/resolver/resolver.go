@@ -0,0 +1,345 @@
+package resolver
+
+import (
+	"context"
+
+	"github.com/lukaskurz/rebyre/pkg/disjunction"
+	"github.com/lukaskurz/rebyre/pkg/literal"
+)
+
+// Verdict is the outcome of a resolution run
+type Verdict int
+
+const (
+	// Unknown means the run was stopped before reaching a verdict
+	Unknown Verdict = iota
+	// Unsatisfiable means the empty clause was derived
+	Unsatisfiable
+	// Satisfiable means the clause set saturated without deriving the empty clause
+	Satisfiable
+)
+
+// String prints the verdict as string
+func (v Verdict) String() string {
+	switch v {
+	case Unsatisfiable:
+		return "UNSATISFIABLE"
+	case Satisfiable:
+		return "SATISFIABLE"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// Mode is the refinement of resolution a Solver uses
+type Mode int
+
+const (
+	// Saturation resolves any two clauses, only restricted by the set of support
+	Saturation Mode = iota
+	// Linear resolves the previous resolvent, the center clause, in every step,
+	// either with an input clause or with one of its ancestors in the chain
+	Linear
+	// Input resolves the previous resolvent with an input clause in every step.
+	// It is only complete for Horn clauses, so a search that finds no refutation
+	// ends with an Unknown verdict.
+	Input
+	// Ordered only resolves two clauses on a variable that is the greatest of
+	// both clauses, in the order given by the Order of the Solver
+	Ordered
+	// PositiveHyper resolves a clause with negative literals against a positive
+	// clause for each of them at once, deriving positive clauses only
+	PositiveHyper
+	// NegativeHyper resolves a clause with positive literals against a negative
+	// clause for each of them at once, deriving negative clauses only
+	NegativeHyper
+	// DavisPutnam eliminates one variable after another, replacing the clauses
+	// containing it by all their resolvents on it
+	DavisPutnam
+)
+
+// Result contains everything a resolution run produced
+type Result struct {
+	Verdict Verdict
+	// Clauses contains the input clauses followed by all derived clauses, in the order they were found.
+	// With Shortest set, only the derived clauses of the kept refutation remain.
+	Clauses []*disjunction.Disjunction
+	// Refutations contains the empty clause that was derived. With Shortest set,
+	// every empty clause of the last step is derived, of which only the one with
+	// the fewest resolution steps is kept
+	Refutations []*disjunction.Disjunction
+	// Model is a satisfying assignment of the input clauses for a satisfiable verdict.
+	// It is verified against the input and left nil if none could be constructed.
+	Model Model
+	// Propagated reports that unit propagation alone derived the empty clause
+	Propagated bool
+	Stats      Stats
+
+	store *disjunction.Store
+	order ordering
+}
+
+// Stats counts what happened during a resolution run
+type Stats struct {
+	// Rounds is the number of resolution rounds that were run, for the
+	// given-clause algorithm it is the number of given clauses
+	Rounds int
+	// Tautologies is the number of input and derived clauses that were discarded for being tautologies
+	Tautologies int
+	// ForwardSubsumed is the number of new clauses that were discarded, because an existing clause subsumed them
+	ForwardSubsumed int
+	// BackwardSubsumed is the number of existing clauses that were retired, because a new clause subsumed them
+	BackwardSubsumed int
+	// UnitResolutions is the number of clauses derived by unit propagation during preprocessing
+	UnitResolutions int
+	// PureLiterals is the number of pure literals whose clauses were removed during preprocessing
+	PureLiterals int
+}
+
+// Clause looks up a clause of the result by its id
+func (r *Result) Clause(id int) *disjunction.Disjunction {
+	return r.store.Get(id)
+}
+
+// Proof returns the clause d together with all clauses it was derived from,
+// each clause exactly once and ordered by the sequence they were found in
+func (r *Result) Proof(d *disjunction.Disjunction) []*disjunction.Disjunction {
+	used := map[int]bool{}
+	pending := []*disjunction.Disjunction{d}
+	for len(pending) > 0 {
+		c := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if c == nil || used[c.ID()] {
+			continue
+		}
+		used[c.ID()] = true
+
+		for _, id := range c.Parents() {
+			pending = append(pending, r.Clause(id))
+		}
+	}
+
+	proof := make([]*disjunction.Disjunction, 0, len(used))
+	for _, c := range r.Clauses {
+		if used[c.ID()] {
+			proof = append(proof, c)
+		}
+	}
+
+	return proof
+}
+
+// Solver runs refutation by resolution on a set of clauses.
+// The zero value is ready to use and a Solver may run several problems concurrently.
+type Solver struct {
+	// Mode restricts which clauses may be resolved, by default the clause set is
+	// saturated. The Selector is only used by the Saturation and Ordered modes.
+	Mode Mode
+	// Order lists variables from the greatest to the smallest for ordered resolution.
+	// The others are smaller than all listed, in the order of their first appearance.
+	Order []string
+	// Selector picks the given clause of each step, if set the given-clause
+	// algorithm is used instead of saturating the clause set level by level
+	Selector Selector
+	// Preprocess enables unit propagation and pure literal elimination before the saturation
+	Preprocess bool
+	// Shortest derives every empty clause of the step that finds the first one,
+	// keeps only the refutation with the fewest resolution steps among them
+	// and drops the derived clauses that don't take part in it
+	Shortest bool
+	// OnInput is called with the numbered copies of the input clauses, if set
+	OnInput func(clauses []*disjunction.Disjunction)
+	// OnPreprocess is called with the clauses derived by unit propagation and the
+	// pure literals that were eliminated, if set
+	OnPreprocess func(derived []*disjunction.Disjunction, pure []*literal.Literal)
+	// OnEliminate is called for each variable the Davis–Putnam procedure eliminates,
+	// with the clauses that contained it and their resolvents, if set
+	OnEliminate func(variable string, removed []*disjunction.Disjunction, derived []*disjunction.Disjunction)
+	// OnRound is called with the clauses derived in each round or by each given clause, if set
+	OnRound func(derived []*disjunction.Disjunction)
+}
+
+// Solve saturates the clauses by resolution until the empty clause is derived
+// or no new clauses can be found. If ctx is cancelled, the partial result is
+// returned with an Unknown verdict together with the context's error.
+//
+// In the linear modes it searches for a chain of resolvents ending with the
+// empty clause instead, which yields no model.
+//
+// The input clauses are not modified, the result works on copies of them
+// that are numbered starting at 1.
+func (s *Solver) Solve(ctx context.Context, clauses []*disjunction.Disjunction) (*Result, error) {
+	result := &Result{
+		store: disjunction.NewStore(),
+	}
+	defer func() {
+		result.Clauses = result.store.All()
+		if s.Shortest && len(result.Refutations) > 0 {
+			result.keepShortest()
+		}
+	}()
+
+	// tautologies are true anyway and only lead to more tautologies,
+	// so they are numbered with the input but never resolved
+	input := make([]*disjunction.Disjunction, len(clauses))
+	resolvable := make([]*disjunction.Disjunction, 0, len(clauses))
+	sos := false
+	for i, c := range clauses {
+		input[i] = result.store.Add(disjunction.New(c.Literals()...))
+		input[i].Goal = c.Goal
+		sos = sos || c.Goal
+		if input[i].IsTautology() {
+			result.Stats.Tautologies++
+			continue
+		}
+		resolvable = append(resolvable, input[i])
+	}
+	if s.OnInput != nil {
+		s.OnInput(input)
+	}
+
+	fixed := Model{}
+	if s.Preprocess {
+		resolvable, fixed = s.preprocess(result, resolvable)
+		if result.Verdict == Unsatisfiable {
+			return result, nil
+		}
+	}
+
+	switch s.Mode {
+	case Linear:
+		// the search leaves no saturated clause set behind, so a model is only
+		// tried to be built from the input clauses, which may well fail
+		err := s.searchLinear(ctx, result, resolvable)
+		if err == nil && result.Verdict == Satisfiable {
+			result.satisfiable(buildModel(resolvable, disjunction.Variables(input)).with(fixed), input)
+		}
+		return result, err
+	case Input:
+		err := s.searchInput(ctx, result, resolvable)
+		if err == nil && result.Verdict == Unknown && horn(resolvable) {
+			result.satisfiable(hornModel(resolvable, disjunction.Variables(input)).with(fixed), input)
+		}
+		return result, err
+	case DavisPutnam:
+		model, err := s.eliminate(ctx, result, resolvable, disjunction.Variables(input))
+		if err == nil && result.Verdict != Unsatisfiable {
+			result.satisfiable(model.with(fixed), input)
+		}
+		return result, err
+	}
+
+	// hyperresolution always resolves with the nuclei among the input clauses,
+	// they can't be left out of the resolution
+	sos = sos && s.Mode != PositiveHyper && s.Mode != NegativeHyper
+
+	// saturation under ordered resolution yields a model when the variables
+	// are assigned from the smallest to the greatest
+	variables := disjunction.Variables(input)
+	if s.Mode == Ordered {
+		result.order = newOrdering(s.Order, variables)
+		variables = result.order.ascending()
+	}
+
+	saturated, err := s.saturate(ctx, result, resolvable, sos)
+	if err != nil || result.Verdict == Unsatisfiable {
+		return result, err
+	}
+
+	model := s.model(saturated, variables).with(fixed)
+	if sos && !model.Satisfies(input) {
+		// the set of support only guarantees a refutation if the other clauses
+		// are satisfiable, which they might not be when no model is found, so
+		// the saturation is continued without the restriction
+		saturated, err = s.saturate(ctx, result, saturated, false)
+		if err != nil || result.Verdict == Unsatisfiable {
+			return result, err
+		}
+		model = s.model(saturated, variables).with(fixed)
+	}
+
+	result.satisfiable(model, input)
+	return result, nil
+}
+
+// keepShortest keeps the refutation whose proof has the fewest derived clauses,
+// counting clauses shared by several branches once. Of the derived clauses only
+// those of the kept refutation remain.
+func (r *Result) keepShortest() {
+	refutation := r.Refutations[0]
+	fewest := len(r.derivedIn(refutation))
+	for _, e := range r.Refutations[1:] {
+		if n := len(r.derivedIn(e)); n < fewest {
+			refutation, fewest = e, n
+		}
+	}
+
+	used := r.derivedIn(refutation)
+	clauses := make([]*disjunction.Disjunction, 0, len(r.Clauses))
+	for _, c := range r.Clauses {
+		if used[c.ID()] || len(c.Parents()) == 0 {
+			clauses = append(clauses, c)
+		}
+	}
+
+	r.Clauses = clauses
+	r.Refutations = []*disjunction.Disjunction{refutation}
+}
+
+// derivedIn returns the ids of the derived clauses in the proof of d
+func (r *Result) derivedIn(d *disjunction.Disjunction) map[int]bool {
+	ids := map[int]bool{}
+	for _, c := range r.Proof(d) {
+		if len(c.Parents()) > 0 {
+			ids[c.ID()] = true
+		}
+	}
+	return ids
+}
+
+// satisfiable sets the verdict and keeps the model if it satisfies the input
+func (r *Result) satisfiable(model Model, input []*disjunction.Disjunction) {
+	r.Verdict = Satisfiable
+	if model.Satisfies(input) {
+		r.Model = model
+	}
+}
+
+// saturate runs the configured saturation algorithm. With sos set, only pairs
+// of clauses are resolved where at least one is a goal, as in the set of
+// support strategy.
+func (s *Solver) saturate(ctx context.Context, result *Result, clauses []*disjunction.Disjunction, sos bool) ([]*disjunction.Disjunction, error) {
+	if s.Mode == PositiveHyper || s.Mode == NegativeHyper {
+		return s.saturateHyper(ctx, result, clauses)
+	}
+	if s.Selector == nil {
+		return s.saturateLevels(ctx, result, clauses, sos)
+	}
+	return s.saturateGiven(ctx, result, clauses, sos)
+}
+
+// model constructs a model from the saturated clauses. The electrons left by
+// positive hyperresolution are positive, so it only assigns true where needed,
+// and negative hyperresolution is its dual.
+func (s *Solver) model(saturated []*disjunction.Disjunction, variables []string) Model {
+	if s.Mode != NegativeHyper {
+		return buildModel(saturated, variables)
+	}
+
+	model := buildModel(negated(saturated), variables)
+	for v := range model {
+		model[v] = !model[v]
+	}
+	return model
+}
+
+func getEmptyClauses(disjunctions []*disjunction.Disjunction) []*disjunction.Disjunction {
+	clauses := make([]*disjunction.Disjunction, 0)
+
+	for _, d := range disjunctions {
+		if d.IsEmpty() {
+			clauses = append(clauses, d)
+		}
+	}
+	return clauses
+}
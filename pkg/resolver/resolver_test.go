@@ -0,0 +1,271 @@
+package resolver
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/lukaskurz/rebyre/pkg/disjunction"
+	"github.com/lukaskurz/rebyre/pkg/internal/clausetest"
+)
+
+var parse = clausetest.Parse
+
+func TestSolveUnsatisfiable(t *testing.T) {
+	clauses := parse(t, "a | b", "!a | b", "a | !b", "!a | !b")
+
+	result, err := (&Solver{}).Solve(context.Background(), clauses)
+	if err != nil {
+		t.Fatalf("FAILED, got an error: %s", err.Error())
+	}
+
+	if result.Verdict != Unsatisfiable {
+		t.Errorf("FAILED, expected verdict to be %s, not %s", Unsatisfiable, result.Verdict)
+	}
+	if len(result.Refutations) == 0 {
+		t.Errorf("FAILED, expected at least one refutation")
+	}
+
+	for _, r := range result.Refutations {
+		proof := result.Proof(r)
+		if proof[len(proof)-1] != r {
+			t.Errorf("FAILED, expected proof to end with the empty clause")
+		}
+		for _, c := range proof {
+			if c.SourceA != 0 && result.Clause(c.SourceA) == nil {
+				t.Errorf("FAILED, source %d of %s is not part of the result", c.SourceA, c)
+			}
+			if c.SourceB != 0 && result.Clause(c.SourceB) == nil {
+				t.Errorf("FAILED, source %d of %s is not part of the result", c.SourceB, c)
+			}
+		}
+	}
+}
+
+func TestSolveSatisfiable(t *testing.T) {
+	clauses := parse(t, "a | b", "!a | b")
+
+	result, err := (&Solver{}).Solve(context.Background(), clauses)
+	if err != nil {
+		t.Fatalf("FAILED, got an error: %s", err.Error())
+	}
+
+	if result.Verdict != Satisfiable {
+		t.Errorf("FAILED, expected verdict to be %s, not %s", Satisfiable, result.Verdict)
+	}
+	if len(result.Refutations) != 0 {
+		t.Errorf("FAILED, expected no refutations, got %d", len(result.Refutations))
+	}
+}
+
+func TestSolveOnRound(t *testing.T) {
+	clauses := parse(t, "a | b", "!a | b")
+
+	derived := 0
+	solver := &Solver{OnRound: func(d []*disjunction.Disjunction) {
+		derived += len(d)
+	}}
+
+	result, err := solver.Solve(context.Background(), clauses)
+	if err != nil {
+		t.Fatalf("FAILED, got an error: %s", err.Error())
+	}
+
+	if derived != len(result.Clauses)-len(clauses) {
+		t.Errorf("FAILED, expected %d derived clauses to be reported, not %d", len(result.Clauses)-len(clauses), derived)
+	}
+}
+
+func TestSolveCancelled(t *testing.T) {
+	clauses := parse(t, "a | b", "!a | b")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result, err := (&Solver{}).Solve(ctx, clauses)
+	if err != context.Canceled {
+		t.Errorf("FAILED, expected error to be %v, not %v", context.Canceled, err)
+	}
+	if result.Verdict != Unknown {
+		t.Errorf("FAILED, expected verdict to be %s, not %s", Unknown, result.Verdict)
+	}
+}
+
+func TestSolveIDsPerProblem(t *testing.T) {
+	clauses := parse(t, "a | b", "!a | b", "a | !b", "!a | !b")
+	solver := &Solver{}
+
+	results := make([]*Result, 4)
+	errs := make([]error, 4)
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = solver.Solve(context.Background(), clauses)
+		}(i)
+	}
+	wg.Wait()
+
+	for i, r := range results {
+		if errs[i] != nil {
+			t.Fatalf("FAILED, got an error: %s", errs[i].Error())
+		}
+		for j, c := range r.Clauses {
+			if c.ID() != j+1 {
+				t.Errorf("FAILED, expected clause %d of result %d to have id %d not %d", j, i, j+1, c.ID())
+			}
+		}
+		if len(r.Clauses) != len(results[0].Clauses) {
+			t.Errorf("FAILED, expected result %d to have %d clauses not %d", i, len(results[0].Clauses), len(r.Clauses))
+		}
+	}
+
+	for _, c := range clauses {
+		if c.ID() != 0 {
+			t.Errorf("FAILED, expected input clause %s to stay unnumbered", c)
+		}
+	}
+}
+
+func TestSolveTautologies(t *testing.T) {
+	clauses := parse(t, "a | !a | b", "!b | c", "c | !c")
+
+	result, err := (&Solver{}).Solve(context.Background(), clauses)
+	if err != nil {
+		t.Fatalf("FAILED, got an error: %s", err.Error())
+	}
+
+	if result.Stats.Tautologies != 2 {
+		t.Errorf("FAILED, expected 2 tautologies to be discarded, not %d", result.Stats.Tautologies)
+	}
+	if len(result.Clauses) != len(clauses) {
+		t.Errorf("FAILED, expected no clause to be derived from tautologies, got %d clauses", len(result.Clauses))
+	}
+	if result.Verdict != Satisfiable || result.Model == nil {
+		t.Errorf("FAILED, expected a model, got %s", result.Verdict)
+	}
+}
+
+func TestSolveSubsumption(t *testing.T) {
+	clauses := parse(t, "a | b | c", "a", "!a | b", "b | d", "!b | e")
+
+	result, err := (&Solver{}).Solve(context.Background(), clauses)
+	if err != nil {
+		t.Fatalf("FAILED, got an error: %s", err.Error())
+	}
+
+	// "a" retires "a | b | c", the resolvent "b" retires "!a | b" and "b | d",
+	// the resolvent "e" retires "!b | e" and the resolvents "!a | e" and "d | e"
+	if result.Stats.BackwardSubsumed != 6 {
+		t.Errorf("FAILED, expected 6 clauses to be retired, not %d", result.Stats.BackwardSubsumed)
+	}
+	if result.Stats.ForwardSubsumed == 0 {
+		t.Errorf("FAILED, expected new clauses to be discarded")
+	}
+	if len(result.Clauses) != len(clauses)+4 {
+		t.Errorf("FAILED, expected 4 clauses to be derived, not %d", len(result.Clauses)-len(clauses))
+	}
+	if result.Verdict != Satisfiable || result.Model == nil {
+		t.Errorf("FAILED, expected a model, got %s", result.Verdict)
+	}
+}
+
+func goals(clauses []*disjunction.Disjunction, indices ...int) []*disjunction.Disjunction {
+	for _, i := range indices {
+		clauses[i].Goal = true
+	}
+	return clauses
+}
+
+func TestSolveSetOfSupport(t *testing.T) {
+	for _, selector := range []Selector{nil, ShortestFirst{}} {
+		clauses := goals(parse(t, "a | b", "!a | b", "c | d", "!c | d", "!b | e", "!b"), 5)
+
+		result, err := (&Solver{Selector: selector}).Solve(context.Background(), clauses)
+		if err != nil {
+			t.Fatalf("FAILED, got an error: %s", err.Error())
+		}
+
+		if result.Verdict != Unsatisfiable {
+			t.Errorf("FAILED, expected verdict to be %s, not %s", Unsatisfiable, result.Verdict)
+		}
+		for _, c := range result.Clauses[len(clauses):] {
+			if !c.Goal {
+				t.Errorf("FAILED, expected %s to be derived from the goal", c)
+			}
+		}
+	}
+}
+
+func TestSolveSetOfSupportInconsistent(t *testing.T) {
+	for _, selector := range []Selector{nil, ShortestFirst{}} {
+		clauses := goals(parse(t, "a", "!a", "c"), 2)
+
+		result, err := (&Solver{Selector: selector}).Solve(context.Background(), clauses)
+		if err != nil {
+			t.Fatalf("FAILED, got an error: %s", err.Error())
+		}
+
+		if result.Verdict != Unsatisfiable {
+			t.Errorf("FAILED, expected verdict to be %s, not %s", Unsatisfiable, result.Verdict)
+		}
+	}
+}
+
+func TestSolveShortest(t *testing.T) {
+	clauses := parse(t, "a | b", "!a | b", "a | !b", "!a | !b", "c", "!c | d", "!d")
+
+	all, err := (&Solver{}).Solve(context.Background(), clauses)
+	if err != nil {
+		t.Fatalf("FAILED, got an error: %s", err.Error())
+	}
+
+	result, err := (&Solver{Shortest: true}).Solve(context.Background(), clauses)
+	if err != nil {
+		t.Fatalf("FAILED, got an error: %s", err.Error())
+	}
+	if len(result.Refutations) != 1 {
+		t.Fatalf("FAILED, expected a single refutation, got %d", len(result.Refutations))
+	}
+
+	// c and !c | d yield d, which clashes with !d
+	steps := len(result.derivedIn(result.Refutations[0]))
+	if steps != 2 {
+		t.Errorf("FAILED, expected the shortest refutation to take 2 steps, not %d", steps)
+	}
+	if n := len(all.derivedIn(all.Refutations[0])); n < steps {
+		t.Errorf("FAILED, expected no refutation with fewer than %d steps, found one with %d", steps, n)
+	}
+
+	if len(result.Clauses) != len(clauses)+steps {
+		t.Errorf("FAILED, expected only the input and the clauses of the proof to remain, got %d clauses", len(result.Clauses))
+	}
+}
+
+func TestSolveShortestFewerSteps(t *testing.T) {
+	// the second round resolves ( d ) with ( !d ) before ( !a ) with ( a )
+	clauses := parse(t, "a", "!a | d", "!a | !d")
+
+	first, err := (&Solver{}).Solve(context.Background(), clauses)
+	if err != nil {
+		t.Fatalf("FAILED, got an error: %s", err.Error())
+	}
+	result, err := (&Solver{Shortest: true}).Solve(context.Background(), clauses)
+	if err != nil {
+		t.Fatalf("FAILED, got an error: %s", err.Error())
+	}
+
+	steps := len(result.derivedIn(result.Refutations[0]))
+	if n := len(first.derivedIn(first.Refutations[0])); steps >= n {
+		t.Errorf("FAILED, expected fewer steps than the %d of the first refutation, got %d", n, steps)
+	}
+	if result.Stats.Rounds != first.Stats.Rounds {
+		t.Errorf("FAILED, expected the shortest proof within the same %d rounds, took %d", first.Stats.Rounds, result.Stats.Rounds)
+	}
+	for _, d := range result.Clauses[len(clauses):] {
+		if !derivedCorrectly(result, d) {
+			t.Errorf("FAILED, %s is no resolvent of its sources", d)
+		}
+	}
+}
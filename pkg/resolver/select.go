@@ -0,0 +1,55 @@
+package resolver
+
+import "github.com/lukaskurz/rebyre/pkg/disjunction"
+
+// Selector is a heuristic of the given-clause algorithm, that picks the next
+// given clause from the set of support
+type Selector interface {
+	// Select returns the index of the next given clause. The clauses are
+	// ordered by age, oldest first, and step counts the clauses given so far.
+	Select(sos []*disjunction.Disjunction, step int) int
+}
+
+// ShortestFirst selects the clause with the fewest literals, the oldest one on ties
+type ShortestFirst struct{}
+
+// Select returns the index of the shortest clause
+func (ShortestFirst) Select(sos []*disjunction.Disjunction, step int) int {
+	shortest := 0
+	for i, c := range sos {
+		if c.Length() < sos[shortest].Length() {
+			shortest = i
+		}
+	}
+	return shortest
+}
+
+// FIFO selects the clauses in the order they were found, which is a breadth-first search
+type FIFO struct{}
+
+// Select returns the index of the oldest clause
+func (FIFO) Select(sos []*disjunction.Disjunction, step int) int {
+	return 0
+}
+
+// AgeWeight selects clauses by their weight, which is the number of literals,
+// and by their age in turns. It picks the shortest clause PickRatio times and
+// then the oldest clause once. Short clauses lead to the empty clause quickly,
+// while the old ones keep long clauses from starving.
+type AgeWeight struct {
+	// PickRatio is the number of shortest clauses picked for each oldest one,
+	// a negative ratio counts as 0 and always picks the oldest clause
+	PickRatio int
+}
+
+// Select returns the index of the oldest clause every PickRatio+1-th step, the shortest otherwise
+func (a AgeWeight) Select(sos []*disjunction.Disjunction, step int) int {
+	ratio := a.PickRatio
+	if ratio < 0 {
+		ratio = 0
+	}
+	if step%(ratio+1) == ratio {
+		return FIFO{}.Select(sos, step)
+	}
+	return ShortestFirst{}.Select(sos, step)
+}
@@ -0,0 +1,29 @@
+package resolver
+
+import (
+	"testing"
+)
+
+func TestSelectors(t *testing.T) {
+	sos := parse(t, "a | b | c", "a | b", "c", "d")
+
+	selections := []struct {
+		name     string
+		selector Selector
+		steps    []int
+	}{
+		{"shortest", ShortestFirst{}, []int{2, 2, 2, 2}},
+		{"fifo", FIFO{}, []int{0, 0, 0, 0}},
+		{"weighted", AgeWeight{PickRatio: 2}, []int{2, 2, 0, 2}},
+		{"weighted without ratio", AgeWeight{}, []int{0, 0, 0, 0}},
+		{"weighted with negative ratio", AgeWeight{PickRatio: -1}, []int{0, 0, 0, 0}},
+	}
+
+	for _, s := range selections {
+		for step, expected := range s.steps {
+			if i := s.selector.Select(sos, step); i != expected {
+				t.Errorf("FAILED, expected %s to select %d in step %d, not %d", s.name, expected, step, i)
+			}
+		}
+	}
+}
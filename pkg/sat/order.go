@@ -0,0 +1,95 @@
+package sat
+
+// order holds the variables to decide next as a binary heap, the one with the
+// highest activity first and the smaller index first among equally active ones.
+// Assigned variables are only removed once they come up, and put back when they
+// are unassigned again.
+type order struct {
+	activity []float64
+	heap     []int
+	// positions holds the index of each variable in the heap, -1 if it isn't in it
+	positions []int
+}
+
+func newOrder(n int) *order {
+	o := &order{
+		activity:  make([]float64, n),
+		heap:      make([]int, n),
+		positions: make([]int, n),
+	}
+	for v := range o.heap {
+		o.heap[v] = v
+		o.positions[v] = v
+	}
+	return o
+}
+
+// before reports if variable a is to be decided before variable b
+func (o *order) before(a int, b int) bool {
+	return o.activity[a] > o.activity[b] || (o.activity[a] == o.activity[b] && a < b)
+}
+
+func (o *order) empty() bool {
+	return len(o.heap) == 0
+}
+
+// push adds v to the heap, unless it is in it already
+func (o *order) push(v int) {
+	if o.positions[v] >= 0 {
+		return
+	}
+	o.heap = append(o.heap, v)
+	o.positions[v] = len(o.heap) - 1
+	o.up(len(o.heap) - 1)
+}
+
+// pop removes and returns the variable to decide next
+func (o *order) pop() int {
+	v := o.heap[0]
+	last := len(o.heap) - 1
+	o.swap(0, last)
+	o.heap = o.heap[:last]
+	o.positions[v] = -1
+	o.down(0)
+	return v
+}
+
+// raise moves v up after its activity increased
+func (o *order) raise(v int) {
+	if i := o.positions[v]; i >= 0 {
+		o.up(i)
+	}
+}
+
+func (o *order) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !o.before(o.heap[i], o.heap[parent]) {
+			return
+		}
+		o.swap(i, parent)
+		i = parent
+	}
+}
+
+func (o *order) down(i int) {
+	for {
+		first := i
+		for _, child := range []int{2*i + 1, 2*i + 2} {
+			if child < len(o.heap) && o.before(o.heap[child], o.heap[first]) {
+				first = child
+			}
+		}
+		if first == i {
+			return
+		}
+		o.swap(i, first)
+		i = first
+	}
+}
+
+func (o *order) swap(i int, j int) {
+	o.heap[i], o.heap[j] = o.heap[j], o.heap[i]
+	o.positions[o.heap[i]] = i
+	o.positions[o.heap[j]] = j
+}
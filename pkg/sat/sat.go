@@ -0,0 +1,526 @@
+package sat
+
+import (
+	"context"
+
+	"github.com/lukaskurz/rebyre/pkg/disjunction"
+)
+
+// Result contains the outcome of a run of the Solver
+type Result struct {
+	// Satisfiable reports wether the clauses have a model
+	Satisfiable bool
+	// Model assigns every variable of the clauses, if they are satisfiable
+	Model map[string]bool
+	// Refutation is the empty clause derived by resolution, if the clauses are
+	// unsatisfiable and the Solver records proofs
+	Refutation *disjunction.Disjunction
+	// Clauses contains the input clauses followed by the clauses derived for the refutation
+	Clauses []*disjunction.Disjunction
+	Stats   Stats
+
+	store *disjunction.Store
+}
+
+// Clause looks up a clause of the result by its id
+func (r *Result) Clause(id int) *disjunction.Disjunction {
+	if r.store == nil {
+		return nil
+	}
+	return r.store.Get(id)
+}
+
+// Stats counts what happened during a run of the Solver
+type Stats struct {
+	// Decisions is the number of variables that were assigned by guessing
+	Decisions int
+	// Propagations is the number of assigned literals whose clauses were visited
+	Propagations int
+	// Conflicts is the number of clauses that were found false
+	Conflicts int
+	// Learned is the number of clauses learned from conflicts
+	Learned int
+	// Restarts is the number of times the search started over with the learned clauses
+	Restarts int
+}
+
+// Solver decides the satisfiability of clauses by conflict driven clause learning (CDCL).
+// The zero value is ready to use and a Solver may run several problems concurrently.
+type Solver struct {
+	// Proof records how every learned clause was derived, so that a refutation
+	// by resolution can be put together if the clauses are unsatisfiable
+	Proof bool
+}
+
+// Solve searches a model of the clauses. If ctx is cancelled, the search is
+// stopped and the context's error returned.
+//
+// Unit propagation uses two watched literals per clause. Every conflict is
+// analyzed down to its first unique implication point, which yields a clause
+// that is learned and tells how far to jump back. The next variable to decide
+// is the one that appeared most in recent conflicts, with the value it had last.
+//
+// Learning a clause amounts to resolving the conflict clause with the reasons of
+// its literals one after another. With Proof set these steps are recorded and
+// replayed once the final conflict is found, which yields a refutation of
+// the input by resolution.
+func (s *Solver) Solve(ctx context.Context, clauses []*disjunction.Disjunction) (*Result, error) {
+	names := disjunction.Variables(clauses)
+	r := newRun(ctx, names)
+	result := &Result{}
+	if s.Proof {
+		r.store = disjunction.NewStore()
+		r.derived = map[*clause]*disjunction.Disjunction{}
+		result.store = r.store
+	}
+	defer func() {
+		result.Stats = r.stats
+	}()
+
+	satisfiable := r.load(clauses)
+	if satisfiable {
+		var err error
+		if satisfiable, err = r.search(); err != nil {
+			return result, err
+		}
+	}
+
+	if !satisfiable {
+		if r.store != nil {
+			result.Refutation = r.resolve(r.refutation)
+			result.Clauses = r.store.All()
+		}
+		return result, nil
+	}
+
+	result.Satisfiable = true
+	result.Model = make(map[string]bool, len(names))
+	for v, name := range names {
+		result.Model[name] = r.values[v] == 1
+	}
+	return result, nil
+}
+
+// lit is a literal of the variable with index lit/2, negated if the lowest bit is set
+type lit int
+
+func newLit(variable int, negated bool) lit {
+	if negated {
+		return lit(2*variable + 1)
+	}
+	return lit(2 * variable)
+}
+
+func (l lit) variable() int {
+	return int(l) >> 1
+}
+
+func (l lit) negated() bool {
+	return l&1 == 1
+}
+
+func (l lit) not() lit {
+	return l ^ 1
+}
+
+type clause struct {
+	literals []lit
+	// input is the clause this one was loaded from, if the proof is recorded
+	input *disjunction.Disjunction
+	// derivation holds the clauses a learned clause was resolved from in that
+	// order, if the proof is recorded
+	derivation []*clause
+}
+
+// run is the state of the Solver on one problem
+type run struct {
+	ctx   context.Context
+	stats Stats
+	index map[string]int
+
+	clauses []*clause
+	// watches holds the clauses watching each literal, which are visited once it is false
+	watches [][]*clause
+
+	// values holds 1 for true, -1 for false and 0 for unassigned variables
+	values []int8
+	levels []int
+	// reasons holds the clause that implied the value of each variable, nil for decisions
+	reasons []*clause
+	trail   []lit
+	// limits holds the length of the trail before each decision
+	limits []int
+	// head is the position in the trail up to which literals were propagated
+	head int
+
+	// order picks the unassigned variable with the highest activity to decide next
+	order     *order
+	increment float64
+	// phases holds the value each variable had last, to be decided again
+	phases []bool
+	seen   []bool
+
+	// store holds the clauses of the proof, it is nil unless the proof is recorded
+	store   *disjunction.Store
+	derived map[*clause]*disjunction.Disjunction
+	// refutation holds the clauses the empty clause is resolved from
+	refutation []*clause
+}
+
+func newRun(ctx context.Context, names []string) *run {
+	n := len(names)
+	r := &run{
+		ctx:       ctx,
+		index:     make(map[string]int, n),
+		watches:   make([][]*clause, 2*n),
+		values:    make([]int8, n),
+		levels:    make([]int, n),
+		reasons:   make([]*clause, n),
+		order:     newOrder(n),
+		increment: 1,
+		phases:    make([]bool, n),
+		seen:      make([]bool, n),
+	}
+	for i, name := range names {
+		r.index[name] = i
+	}
+	return r
+}
+
+// load adds the clauses and propagates the units among them. It reports false
+// if that already leads to a conflict. Tautologies are left out, as they never
+// propagate anything.
+func (r *run) load(clauses []*disjunction.Disjunction) bool {
+	units := make([]*clause, 0)
+	for _, d := range clauses {
+		c := &clause{}
+		if r.store != nil {
+			c.input = r.store.Add(disjunction.New(d.Literals()...))
+		}
+		tautology := false
+		for _, l := range d.Literals() {
+			x := newLit(r.index[l.Variable()], l.Negated())
+			switch {
+			case hasLit(c.literals, x.not()):
+				tautology = true
+			case !hasLit(c.literals, x):
+				c.literals = append(c.literals, x)
+			}
+		}
+		if tautology {
+			continue
+		}
+
+		r.clauses = append(r.clauses, c)
+		switch len(c.literals) {
+		case 0:
+			r.refute(c)
+			return false
+		case 1:
+			units = append(units, c)
+		default:
+			r.watch(c)
+		}
+	}
+
+	for _, c := range units {
+		switch r.value(c.literals[0]) {
+		case -1:
+			r.refute(c)
+			return false
+		case 0:
+			r.assign(c.literals[0], c)
+		}
+	}
+	if conflict := r.propagate(); conflict != nil {
+		r.refute(conflict)
+		return false
+	}
+	return true
+}
+
+// search decides and propagates literals until every variable is assigned or a
+// conflict can't be resolved by jumping back, because no decision led to it
+func (r *run) search() (bool, error) {
+	restart := 100
+	sinceRestart := 0
+
+	for {
+		if conflict := r.propagate(); conflict != nil {
+			r.stats.Conflicts++
+			if err := r.ctx.Err(); err != nil {
+				return false, err
+			}
+			if r.level() == 0 {
+				r.refute(conflict)
+				return false, nil
+			}
+
+			learned, level := r.analyze(conflict)
+			r.backtrack(level)
+			r.clauses = append(r.clauses, learned)
+			if len(learned.literals) > 1 {
+				r.watch(learned)
+			}
+			r.assign(learned.literals[0], learned)
+			r.stats.Learned++
+			r.increment /= 0.95
+
+			sinceRestart++
+			if sinceRestart == restart {
+				r.backtrack(0)
+				r.stats.Restarts++
+				sinceRestart = 0
+				restart += restart / 2
+			}
+			continue
+		}
+
+		v := r.pick()
+		if v < 0 {
+			return true, nil
+		}
+		r.limits = append(r.limits, len(r.trail))
+		r.stats.Decisions++
+		r.assign(newLit(v, !r.phases[v]), nil)
+	}
+}
+
+func (r *run) level() int {
+	return len(r.limits)
+}
+
+// value returns 1 if l is true, -1 if it is false and 0 if it is unassigned
+func (r *run) value(l lit) int8 {
+	if l.negated() {
+		return -r.values[l.variable()]
+	}
+	return r.values[l.variable()]
+}
+
+// watch lets the first two literals of c watch it
+func (r *run) watch(c *clause) {
+	r.watches[c.literals[0]] = append(r.watches[c.literals[0]], c)
+	r.watches[c.literals[1]] = append(r.watches[c.literals[1]], c)
+}
+
+// assign makes l true on the current decision level, implied by reason
+func (r *run) assign(l lit, reason *clause) {
+	v := l.variable()
+	r.values[v] = 1
+	if l.negated() {
+		r.values[v] = -1
+	}
+	r.levels[v] = r.level()
+	r.reasons[v] = reason
+	r.trail = append(r.trail, l)
+}
+
+// backtrack undoes all assignments above the given decision level
+func (r *run) backtrack(level int) {
+	if r.level() <= level {
+		return
+	}
+	for _, l := range r.trail[r.limits[level]:] {
+		v := l.variable()
+		r.phases[v] = r.values[v] == 1
+		r.values[v] = 0
+		r.reasons[v] = nil
+		r.order.push(v)
+	}
+	r.trail = r.trail[:r.limits[level]]
+	r.limits = r.limits[:level]
+	r.head = len(r.trail)
+}
+
+// propagate assigns the literals implied by unit clauses until nothing is left
+// to propagate, or returns the clause all of whose literals became false.
+//
+// The watched literals of a clause are its first two. Once one of them is false,
+// another literal that isn't false takes its place, if there is none the other
+// watched literal is implied.
+func (r *run) propagate() *clause {
+	for r.head < len(r.trail) {
+		falsified := r.trail[r.head].not()
+		r.head++
+		r.stats.Propagations++
+
+		watchers := r.watches[falsified]
+		kept := watchers[:0]
+		for i, c := range watchers {
+			if c.literals[0] == falsified {
+				c.literals[0], c.literals[1] = c.literals[1], c.literals[0]
+			}
+			if r.value(c.literals[0]) == 1 {
+				kept = append(kept, c)
+				continue
+			}
+
+			moved := false
+			for k := 2; k < len(c.literals); k++ {
+				if r.value(c.literals[k]) != -1 {
+					c.literals[1], c.literals[k] = c.literals[k], c.literals[1]
+					r.watches[c.literals[1]] = append(r.watches[c.literals[1]], c)
+					moved = true
+					break
+				}
+			}
+			if moved {
+				continue
+			}
+
+			kept = append(kept, c)
+			if r.value(c.literals[0]) == -1 {
+				r.watches[falsified] = append(kept, watchers[i+1:]...)
+				r.head = len(r.trail)
+				return c
+			}
+			r.assign(c.literals[0], c)
+		}
+		r.watches[falsified] = kept
+	}
+	return nil
+}
+
+// analyze resolves the conflict clause with the reasons of its literals from the
+// latest decision level, in reverse order of the trail, until only one literal of
+// that level is left, the first unique implication point. The resulting clause
+// is returned with that literal first and one of the highest remaining level
+// second, together with that level to jump back to.
+func (r *run) analyze(conflict *clause) (*clause, int) {
+	learned := &clause{literals: []lit{0}}
+	pending := 0
+	index := len(r.trail) - 1
+	reason := conflict
+	var pivot lit = -1
+
+	for {
+		if r.store != nil {
+			learned.derivation = append(learned.derivation, reason)
+		}
+		for _, q := range reason.literals {
+			v := q.variable()
+			if q == pivot || r.seen[v] {
+				continue
+			}
+			r.seen[v] = true
+			r.bump(v)
+			if r.levels[v] == r.level() {
+				pending++
+			} else {
+				learned.literals = append(learned.literals, q)
+			}
+		}
+
+		for !r.seen[r.trail[index].variable()] {
+			index--
+		}
+		pivot = r.trail[index]
+		index--
+		r.seen[pivot.variable()] = false
+		reason = r.reasons[pivot.variable()]
+		pending--
+		if pending == 0 {
+			break
+		}
+	}
+	learned.literals[0] = pivot.not()
+
+	level := 0
+	for i, l := range learned.literals[1:] {
+		r.seen[l.variable()] = false
+		if r.levels[l.variable()] > level {
+			level = r.levels[l.variable()]
+			learned.literals[1], learned.literals[i+1] = learned.literals[i+1], learned.literals[1]
+		}
+	}
+
+	return learned, level
+}
+
+// refute records the clauses the empty clause is resolved from, which are the
+// conflict on the lowest decision level and the reasons of its literals, in
+// reverse order of the trail
+func (r *run) refute(conflict *clause) {
+	if r.store == nil {
+		return
+	}
+
+	r.refutation = []*clause{conflict}
+	for _, l := range conflict.literals {
+		r.seen[l.variable()] = true
+	}
+	for i := len(r.trail) - 1; i >= 0; i-- {
+		v := r.trail[i].variable()
+		if !r.seen[v] {
+			continue
+		}
+		r.seen[v] = false
+		reason := r.reasons[v]
+		r.refutation = append(r.refutation, reason)
+		for _, l := range reason.literals {
+			if l.variable() != v {
+				r.seen[l.variable()] = true
+			}
+		}
+	}
+}
+
+// resolve replays a derivation, resolving its first clause with the others one
+// after another, and returns the last resolvent. Each resolvent is added to the store.
+//
+// All literals but the one of the reason that is resolved on are false at the
+// time, so every pair of clauses clashes on exactly one variable.
+func (r *run) resolve(derivation []*clause) *disjunction.Disjunction {
+	d := r.derive(derivation[0])
+	for _, c := range derivation[1:] {
+		d = r.store.Add(d.Derive(r.derive(c)))
+	}
+	return d
+}
+
+// derive returns the clause of the proof corresponding to c, a learned
+// clause is derived the first time it is needed
+func (r *run) derive(c *clause) *disjunction.Disjunction {
+	if c.input != nil {
+		return c.input
+	}
+	if d, ok := r.derived[c]; ok {
+		return d
+	}
+	d := r.resolve(c.derivation)
+	r.derived[c] = d
+	return d
+}
+
+// bump raises the activity of a variable found in a conflict
+func (r *run) bump(v int) {
+	activity := r.order.activity
+	activity[v] += r.increment
+	if activity[v] > 1e100 {
+		for i := range activity {
+			activity[i] *= 1e-100
+		}
+		r.increment *= 1e-100
+	}
+	r.order.raise(v)
+}
+
+// pick returns the unassigned variable with the highest activity, or -1 if all are assigned
+func (r *run) pick() int {
+	for !r.order.empty() {
+		if v := r.order.pop(); r.values[v] == 0 {
+			return v
+		}
+	}
+	return -1
+}
+
+func hasLit(literals []lit, l lit) bool {
+	for _, other := range literals {
+		if other == l {
+			return true
+		}
+	}
+	return false
+}
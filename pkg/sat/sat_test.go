@@ -0,0 +1,239 @@
+package sat
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+
+	"github.com/lukaskurz/rebyre/pkg/disjunction"
+	"github.com/lukaskurz/rebyre/pkg/internal/clausetest"
+	"github.com/lukaskurz/rebyre/pkg/literal"
+)
+
+var parse = clausetest.Parse
+
+func TestSolve(t *testing.T) {
+	tests := []struct {
+		clauses     []string
+		satisfiable bool
+	}{
+		{[]string{"a"}, true},
+		{[]string{"a", "!a"}, false},
+		{[]string{"a | b", "!a | b", "a | !b", "!a | !b"}, false},
+		{[]string{"a | b", "!a | c", "!b | c", "!c | d"}, true},
+		{[]string{"a | !a", "b"}, true},
+		{[]string{"a | a", "!a | !a"}, false},
+		{[]string{"a | b | c", "!a | !b", "!b | !c", "!a | !c", "!a", "!b"}, true},
+		{[]string{"a | b", "!a | c", "!b | c", "!c"}, false},
+	}
+
+	for _, test := range tests {
+		clauses := parse(t, test.clauses...)
+		result, err := (&Solver{}).Solve(context.Background(), clauses)
+		if err != nil {
+			t.Fatalf("FAILED, got an error for %v: %s", test.clauses, err.Error())
+		}
+
+		if result.Satisfiable != test.satisfiable {
+			t.Errorf("FAILED, expected %v to be satisfiable %t", test.clauses, test.satisfiable)
+			continue
+		}
+		if test.satisfiable && !clausetest.Satisfies(result.Model, clauses) {
+			t.Errorf("FAILED, expected the model %v to satisfy %v", result.Model, test.clauses)
+		}
+	}
+}
+
+func TestSolveEmptyClause(t *testing.T) {
+	clauses := []*disjunction.Disjunction{disjunction.New(), disjunction.New(literal.New("a", false))}
+	result, err := (&Solver{}).Solve(context.Background(), clauses)
+	if err != nil {
+		t.Fatalf("FAILED, got an error: %s", err.Error())
+	}
+	if result.Satisfiable {
+		t.Errorf("FAILED, expected the empty clause to be unsatisfiable")
+	}
+}
+
+// pigeons returns the clauses stating that n+1 pigeons sit in n holes, no two in the same
+func pigeons(n int) []*disjunction.Disjunction {
+	sits := func(p, h int) string {
+		return fmt.Sprintf("p%dh%d", p, h)
+	}
+
+	clauses := make([]*disjunction.Disjunction, 0)
+	for p := 0; p <= n; p++ {
+		literals := make([]*literal.Literal, n)
+		for h := range literals {
+			literals[h] = literal.New(sits(p, h), false)
+		}
+		clauses = append(clauses, disjunction.New(literals...))
+	}
+	for h := 0; h < n; h++ {
+		for p := 0; p <= n; p++ {
+			for q := p + 1; q <= n; q++ {
+				clauses = append(clauses, disjunction.New(literal.New(sits(p, h), true), literal.New(sits(q, h), true)))
+			}
+		}
+	}
+	return clauses
+}
+
+func TestSolvePigeonhole(t *testing.T) {
+	result, err := (&Solver{}).Solve(context.Background(), pigeons(5))
+	if err != nil {
+		t.Fatalf("FAILED, got an error: %s", err.Error())
+	}
+
+	if result.Satisfiable {
+		t.Errorf("FAILED, expected 6 pigeons not to fit into 5 holes")
+	}
+	if result.Stats.Conflicts == 0 || result.Stats.Learned == 0 {
+		t.Errorf("FAILED, expected conflicts and learned clauses, got %+v", result.Stats)
+	}
+}
+
+// checkProof verifies that the refutation of the result is the empty clause and
+// each of its clauses is either one of the input clauses or the resolvent of its sources
+func checkProof(t *testing.T, result *Result, clauses []*disjunction.Disjunction) bool {
+	if result.Refutation == nil || !result.Refutation.IsEmpty() {
+		t.Errorf("FAILED, expected the empty clause as refutation, not %v", result.Refutation)
+		return false
+	}
+
+	pending := []*disjunction.Disjunction{result.Refutation}
+	for len(pending) > 0 {
+		d := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		if d.SourceA == 0 {
+			input := false
+			for _, c := range clauses {
+				input = input || c.Equals(d)
+			}
+			if !input {
+				t.Errorf("FAILED, expected %s to be an input clause", d)
+				return false
+			}
+			continue
+		}
+
+		a, b := result.Clause(d.SourceA), result.Clause(d.SourceB)
+		if a == nil || b == nil || !a.CompatibleWith(b) || !a.Derive(b).Equals(d) {
+			t.Errorf("FAILED, expected %s to be the resolvent of its sources %v and %v", d, a, b)
+			return false
+		}
+		pending = append(pending, a, b)
+	}
+	return true
+}
+
+func TestSolveProof(t *testing.T) {
+	tests := [][]*disjunction.Disjunction{
+		parse(t, "a | b", "!a | b", "a | !b", "!a | !b"),
+		parse(t, "a", "!a"),
+		parse(t, "a | b", "!b", "!a | c", "!c"),
+		{disjunction.New()},
+		pigeons(4),
+	}
+
+	for _, clauses := range tests {
+		result, err := (&Solver{Proof: true}).Solve(context.Background(), clauses)
+		if err != nil {
+			t.Fatalf("FAILED, got an error: %s", err.Error())
+		}
+		if result.Satisfiable {
+			t.Errorf("FAILED, expected %v to be unsatisfiable", clauses)
+			continue
+		}
+		checkProof(t, result, clauses)
+
+		if len(result.Clauses) < len(clauses) || result.Clauses[len(result.Clauses)-1] != result.Refutation {
+			t.Errorf("FAILED, expected the input followed by the derived clauses, ending with the refutation")
+		}
+	}
+}
+
+func TestSolveWithoutProof(t *testing.T) {
+	result, err := (&Solver{}).Solve(context.Background(), parse(t, "a", "!a"))
+	if err != nil {
+		t.Fatalf("FAILED, got an error: %s", err.Error())
+	}
+	if result.Refutation != nil || result.Clauses != nil || result.Clause(1) != nil {
+		t.Errorf("FAILED, expected no proof to be recorded")
+	}
+}
+
+func TestSolveCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := (&Solver{}).Solve(ctx, pigeons(6))
+	if err != context.Canceled {
+		t.Errorf("FAILED, expected the search to be cancelled, got %v", err)
+	}
+}
+
+// cnf is a random clause set, generated by testing/quick
+type cnf []*disjunction.Disjunction
+
+// Generate creates up to 40 clauses of 1 to 4 literals over the variables a to h
+func (cnf) Generate(r *rand.Rand, size int) reflect.Value {
+	clauses := clausetest.Generator{Clauses: 40, Width: 4, Variables: 8}.Generate(r)
+	return reflect.ValueOf(cnf(clauses))
+}
+
+func TestSolveProperty(t *testing.T) {
+	check := func(c cnf, proof bool) bool {
+		result, err := (&Solver{Proof: proof}).Solve(context.Background(), c)
+		if err != nil {
+			t.Errorf("FAILED, got an error for %v: %s", c, err.Error())
+			return false
+		}
+
+		if result.Satisfiable != clausetest.Satisfiable(c) {
+			t.Errorf("FAILED, expected %v to be satisfiable %t", c, !result.Satisfiable)
+			return false
+		}
+		if result.Satisfiable && !clausetest.Satisfies(result.Model, c) {
+			t.Errorf("FAILED, expected the model %v to satisfy %v", result.Model, c)
+			return false
+		}
+		if !result.Satisfiable && proof {
+			return checkProof(t, result, c)
+		}
+		return true
+	}
+
+	if err := quick.Check(check, &quick.Config{MaxCount: 1000}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestOrder(t *testing.T) {
+	o := newOrder(4)
+	o.activity[2] = 3
+	o.raise(2)
+	o.activity[3] = 1
+	o.raise(3)
+
+	if v := o.pop(); v != 2 {
+		t.Errorf("FAILED, expected the most active variable 2 first, got %d", v)
+	}
+	if v := o.pop(); v != 3 {
+		t.Errorf("FAILED, expected variable 3 second, got %d", v)
+	}
+	o.push(2)
+	o.push(2)
+	for _, expected := range []int{2, 0, 1} {
+		if v := o.pop(); v != expected {
+			t.Errorf("FAILED, expected variable %d, got %d", expected, v)
+		}
+	}
+	if !o.empty() {
+		t.Errorf("FAILED, expected every variable to be popped once")
+	}
+}